@@ -11,7 +11,6 @@ resource "gsuite_group" "devteam" {
 resource "gsuite_group_settings" "devteam" {
   email = "${gsuite_group.devteam.email}"
 
-  allow_external_members  = true
-  show_in_group_directory = true
-  who_can_discover_group  = "ALL_IN_DOMAIN_CAN_DISCOVER"
+  allow_external_members = true
+  who_can_discover_group = "ALL_IN_DOMAIN_CAN_DISCOVER"
 }
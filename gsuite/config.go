@@ -16,8 +16,13 @@ import (
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/jwt"
 	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/cloudidentity/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
 	groupSettings "google.golang.org/api/groupssettings/v1"
 	"google.golang.org/api/impersonate"
+	"google.golang.org/api/licensing/v1"
 	"google.golang.org/api/option"
 )
 
@@ -46,6 +51,14 @@ type Config struct {
 	directory *directory.Service
 
 	groupSettings *groupSettings.Service
+
+	cloudIdentity *cloudidentity.Service
+
+	// Kept to act as other users of the domain, see userClientOptions
+	account        accountFile
+	serviceAccount string
+	userAgent      string
+	userClients    *userClientCache
 }
 
 // loadAndValidate loads the application default credentials from the
@@ -55,6 +68,7 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 	var account accountFile
 
 	oauthScopes := c.OauthScopes
+	c.userClients = newUserClientCache(defaultUserClientCacheSize)
 
 	var client *http.Client
 	clientOptions := []option.ClientOption{}
@@ -88,6 +102,7 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 		}
 
 		conf.Subject = c.ImpersonatedUserEmail
+		c.account = account
 
 		// Initiate an http.Client. The following GET request will be
 		// authorized and authenticated on the behalf of
@@ -100,6 +115,7 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 			return errors.Wrap(err, "failed to get service account from metadata server")
 		}
 		log.Printf("[INFO] Authenticating using credentials from metadata server")
+		c.serviceAccount = serviceAccount
 
 		tokenSource, err := impersonate.CredentialsTokenSource(context.Background(), impersonate.CredentialsConfig{
 			TargetPrincipal: serviceAccount,
@@ -131,6 +147,7 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 	userAgent := fmt.Sprintf("(%s %s) Terraform/%s",
 		runtime.GOOS, runtime.GOARCH, terraformVersion)
 	context := context.Background()
+	c.userAgent = userAgent
 
 	// Create the directory service.
 	directorySvc, err := directory.NewService(context, clientOptions...)
@@ -148,9 +165,141 @@ func (c *Config) loadAndValidate(terraformVersion string) error {
 	groupSettingsSvc.UserAgent = userAgent
 	c.groupSettings = groupSettingsSvc
 
+	// Create the cloudIdentity service.
+	cloudIdentitySvc, err := cloudidentity.NewService(context, clientOptions...)
+	if err != nil {
+		return err
+	}
+	cloudIdentitySvc.UserAgent = userAgent
+	c.cloudIdentity = cloudIdentitySvc
+
 	return nil
 }
 
+// resolveCustomerId returns the ID of the configured customer, fetching it
+// when "my_customer" is used, which only the Admin SDK accepts.
+func (c *Config) resolveCustomerId() (string, error) {
+	if c.CustomerId != "" && c.CustomerId != "my_customer" {
+		return c.CustomerId, nil
+	}
+
+	var customer *directory.Customer
+	var err error
+	err = retry(func() error {
+		customer, err = c.directory.Customers.Get("my_customer").Do()
+		return err
+	}, c.TimeoutMinutes)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error fetching the customer ID: %s", err)
+	}
+
+	return customer.Id, nil
+}
+
+// userClientOptions returns the client options to call an API as the given
+// user of the domain, using the domain-wide delegation of the service account.
+// The clients are cached per user and set of scopes.
+func (c *Config) userClientOptions(subject string, scopes []string) ([]option.ClientOption, error) {
+	if c.userClients == nil {
+		return c.newUserClientOptions(subject, scopes)
+	}
+	return c.userClients.get(userClientKey(subject, scopes), func() ([]option.ClientOption, error) {
+		log.Printf("[DEBUG] Creating client for %s with scopes %s", subject, scopes)
+		return c.newUserClientOptions(subject, scopes)
+	})
+}
+
+func (c *Config) newUserClientOptions(subject string, scopes []string) ([]option.ClientOption, error) {
+	if c.account.ClientEmail != "" {
+		conf := jwt.Config{
+			Email:      c.account.ClientEmail,
+			PrivateKey: []byte(c.account.PrivateKey),
+			Scopes:     scopes,
+			TokenURL:   "https://oauth2.googleapis.com/token",
+			Subject:    subject,
+		}
+
+		client := conf.Client(context.Background())
+		client.Transport = logging.NewTransport("Google", client.Transport)
+		return []option.ClientOption{option.WithHTTPClient(client)}, nil
+	}
+
+	if c.serviceAccount != "" {
+		tokenSource, err := impersonate.CredentialsTokenSource(context.Background(), impersonate.CredentialsConfig{
+			TargetPrincipal: c.serviceAccount,
+			Scopes:          scopes,
+			Subject:         subject,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create impersonated token source")
+		}
+		return []option.ClientOption{option.WithTokenSource(tokenSource)}, nil
+	}
+
+	return nil, fmt.Errorf("acting as %s requires domain-wide delegation: set credentials, or impersonated_user_email when using the metadata server", subject)
+}
+
+// gmailService returns a Gmail service acting as the given user.
+func (c *Config) gmailService(user string, scopes []string) (*gmail.Service, error) {
+	clientOptions, err := c.userClientOptions(user, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	gmailSvc, err := gmail.NewService(context.Background(), clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	gmailSvc.UserAgent = c.userAgent
+	return gmailSvc, nil
+}
+
+// calendarService returns a Calendar service acting as the given user.
+func (c *Config) calendarService(user string) (*calendar.Service, error) {
+	clientOptions, err := c.userClientOptions(user, calendarScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarSvc, err := calendar.NewService(context.Background(), clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	calendarSvc.UserAgent = c.userAgent
+	return calendarSvc, nil
+}
+
+// driveService returns a Drive service acting as the given user.
+func (c *Config) driveService(user string) (*drive.Service, error) {
+	clientOptions, err := c.userClientOptions(user, driveScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	driveSvc, err := drive.NewService(context.Background(), clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	driveSvc.UserAgent = c.userAgent
+	return driveSvc, nil
+}
+
+// licensingService returns an Enterprise License Manager service acting as
+// impersonated_user_email.
+func (c *Config) licensingService() (*licensing.Service, error) {
+	clientOptions, err := c.userClientOptions(c.ImpersonatedUserEmail, licensingScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	licensingSvc, err := licensing.NewService(context.Background(), clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	licensingSvc.UserAgent = c.userAgent
+	return licensingSvc, nil
+}
+
 // accountFile represents the structure of the account file JSON file.
 type accountFile struct {
 	PrivateKeyId string `json:"private_key_id"`
@@ -0,0 +1,166 @@
+package gsuite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataChromeosDevices() *schema.Resource {
+	return &schema.Resource{
+		Read: dataChromeosDevicesRead,
+		Schema: map[string]*schema.Schema{
+			// Search query, e.g. "status:provisioned user:john"
+			"query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"org_unit_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// FULL also returns the recent users of the devices
+			"projection": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "BASIC",
+				ValidateFunc: validation.StringInSlice([]string{"BASIC", "FULL"}, false),
+			},
+
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"device_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"org_unit_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"annotated_user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"annotated_location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"annotated_asset_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"notes": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_sync": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_enrollment_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"recent_users": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataChromeosDevicesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	query, orgUnitPath := d.Get("query").(string), d.Get("org_unit_path").(string)
+
+	var devices []*directory.ChromeOsDevice
+	err := retry(func() error {
+		devices = nil
+		call := config.directory.Chromeosdevices.List(config.CustomerId).
+			Projection(d.Get("projection").(string)).
+			MaxResults(300)
+		if query != "" {
+			call = call.Query(query)
+		}
+		if orgUnitPath != "" {
+			call = call.OrgUnitPath(orgUnitPath)
+		}
+		return call.Pages(context.Background(), func(list *directory.ChromeOsDevices) error {
+			devices = append(devices, list.Chromeosdevices...)
+			return nil
+		})
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing ChromeOS devices: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", config.CustomerId, orgUnitPath, query))
+	if err = d.Set("devices", flattenChromeosDevices(devices)); err != nil {
+		return fmt.Errorf("Error setting devices in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+func flattenChromeosDevices(devices []*directory.ChromeOsDevice) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(devices))
+	for _, device := range devices {
+		recentUsers := make([]string, 0, len(device.RecentUsers))
+		for _, user := range device.RecentUsers {
+			if user.Email != "" {
+				recentUsers = append(recentUsers, user.Email)
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"device_id":            device.DeviceId,
+			"serial_number":        device.SerialNumber,
+			"model":                device.Model,
+			"status":               device.Status,
+			"org_unit_path":        device.OrgUnitPath,
+			"annotated_user":       device.AnnotatedUser,
+			"annotated_location":   device.AnnotatedLocation,
+			"annotated_asset_id":   device.AnnotatedAssetId,
+			"notes":                device.Notes,
+			"os_version":           device.OsVersion,
+			"mac_address":          device.MacAddress,
+			"last_sync":            device.LastSync,
+			"last_enrollment_time": device.LastEnrollmentTime,
+			"recent_users":         recentUsers,
+		})
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package gsuite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/licensing/v1"
+)
+
+func dataLicenseAssignments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataLicenseAssignmentsRead,
+		Schema: map[string]*schema.Schema{
+			"product_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Limits the assignments to a SKU of the product
+			"sku_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"assignments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sku_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sku_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			// Number of assigned licenses per SKU
+			"sku_counts": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataLicenseAssignmentsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	licensingSvc, err := config.licensingService()
+	if err != nil {
+		return err
+	}
+
+	customerID, err := config.resolveCustomerId()
+	if err != nil {
+		return err
+	}
+
+	productID, skuID := d.Get("product_id").(string), d.Get("sku_id").(string)
+
+	var assignments []*licensing.LicenseAssignment
+	err = retry(func() error {
+		assignments = nil
+		collect := func(list *licensing.LicenseAssignmentList) error {
+			assignments = append(assignments, list.Items...)
+			return nil
+		}
+		if skuID != "" {
+			return licensingSvc.LicenseAssignments.ListForProductAndSku(productID, skuID, customerID).
+				MaxResults(1000).Pages(context.Background(), collect)
+		}
+		return licensingSvc.LicenseAssignments.ListForProduct(productID, customerID).
+			MaxResults(1000).Pages(context.Background(), collect)
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing license assignments of %s: %s", productID, err)
+	}
+
+	result, skuCounts := flattenLicenseAssignments(assignments)
+
+	if skuID != "" {
+		d.SetId(fmt.Sprintf("%s/%s", productID, skuID))
+	} else {
+		d.SetId(productID)
+	}
+	if err = d.Set("assignments", result); err != nil {
+		return fmt.Errorf("Error setting assignments in state: %s", err.Error())
+	}
+	if err = d.Set("sku_counts", skuCounts); err != nil {
+		return fmt.Errorf("Error setting sku_counts in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+func flattenLicenseAssignments(assignments []*licensing.LicenseAssignment) ([]map[string]interface{}, map[string]int) {
+	result := make([]map[string]interface{}, 0, len(assignments))
+	skuCounts := map[string]int{}
+	for _, assignment := range assignments {
+		result = append(result, map[string]interface{}{
+			"user":         strings.ToLower(assignment.UserId),
+			"product_id":   assignment.ProductId,
+			"product_name": assignment.ProductName,
+			"sku_id":       assignment.SkuId,
+			"sku_name":     assignment.SkuName,
+		})
+		skuCounts[assignment.SkuId]++
+	}
+	return result, skuCounts
+}
@@ -0,0 +1,31 @@
+package gsuite
+
+import (
+	"testing"
+
+	"google.golang.org/api/licensing/v1"
+)
+
+func TestFlattenLicenseAssignments(t *testing.T) {
+	assignments := []*licensing.LicenseAssignment{
+		{UserId: "John@domain.ext", ProductId: "Google-Apps", SkuId: "1010020025", SkuName: "Google Workspace Business Plus"},
+		{UserId: "jane@domain.ext", ProductId: "Google-Apps", SkuId: "1010020025", SkuName: "Google Workspace Business Plus"},
+		{UserId: "ceo@domain.ext", ProductId: "Google-Apps", SkuId: "1010020020", SkuName: "Google Workspace Enterprise Plus"},
+	}
+
+	result, skuCounts := flattenLicenseAssignments(assignments)
+	if len(result) != 3 {
+		t.Fatalf("expected 3 assignments, got %d", len(result))
+	}
+	if result[0]["user"] != "john@domain.ext" {
+		t.Errorf("expected user john@domain.ext, got %s", result[0]["user"])
+	}
+	if skuCounts["1010020025"] != 2 || skuCounts["1010020020"] != 1 || len(skuCounts) != 2 {
+		t.Errorf("unexpected sku counts %v", skuCounts)
+	}
+
+	result, skuCounts = flattenLicenseAssignments(nil)
+	if len(result) != 0 || len(skuCounts) != 0 {
+		t.Errorf("expected no assignments, got %v and %v", result, skuCounts)
+	}
+}
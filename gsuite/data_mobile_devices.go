@@ -0,0 +1,152 @@
+package gsuite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataMobileDevices() *schema.Resource {
+	return &schema.Resource{
+		Read: dataMobileDevicesRead,
+		Schema: map[string]*schema.Schema{
+			// Search query, e.g. "status:approved os:Android"
+			"query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// FULL also returns the security details of the devices
+			"projection": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "BASIC",
+				ValidateFunc: validation.StringInSlice([]string{"BASIC", "FULL"}, false),
+			},
+
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"name": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"first_sync": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_sync": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_compromised_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_password_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"encryption_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataMobileDevicesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	query := d.Get("query").(string)
+
+	var devices []*directory.MobileDevice
+	err := retry(func() error {
+		devices = nil
+		call := config.directory.Mobiledevices.List(config.CustomerId).
+			Projection(d.Get("projection").(string)).
+			MaxResults(100)
+		if query != "" {
+			call = call.Query(query)
+		}
+		return call.Pages(context.Background(), func(list *directory.MobileDevices) error {
+			devices = append(devices, list.Mobiledevices...)
+			return nil
+		})
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing mobile devices: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", config.CustomerId, query))
+	if err = d.Set("devices", flattenMobileDevices(devices)); err != nil {
+		return fmt.Errorf("Error setting devices in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+func flattenMobileDevices(devices []*directory.MobileDevice) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(devices))
+	for _, device := range devices {
+		result = append(result, map[string]interface{}{
+			"resource_id":               device.ResourceId,
+			"device_id":                 device.DeviceId,
+			"email":                     device.Email,
+			"name":                      device.Name,
+			"type":                      device.Type,
+			"status":                    device.Status,
+			"model":                     device.Model,
+			"os":                        device.Os,
+			"serial_number":             device.SerialNumber,
+			"first_sync":                device.FirstSync,
+			"last_sync":                 device.LastSync,
+			"device_compromised_status": device.DeviceCompromisedStatus,
+			"device_password_status":    device.DevicePasswordStatus,
+			"encryption_status":         device.EncryptionStatus,
+		})
+	}
+	return result
+}
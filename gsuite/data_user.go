@@ -123,8 +123,9 @@ func dataUser() *schema.Resource {
 			},
 
 			"password": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 
 			// md5, sha-1 and crypt
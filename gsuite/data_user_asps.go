@@ -0,0 +1,83 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUserAsps() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserAspsRead,
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			"asps": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"last_time_used": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataUserAspsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+
+	var asps *directory.Asps
+	var err error
+	err = retry(func() error {
+		asps, err = config.directory.Asps.List(primaryEmail).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing application-specific passwords of user %s: %s", primaryEmail, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(asps.Items))
+	for _, asp := range asps.Items {
+		result = append(result, map[string]interface{}{
+			"code_id":        asp.CodeId,
+			"name":           asp.Name,
+			"creation_time":  asp.CreationTime,
+			"last_time_used": asp.LastTimeUsed,
+		})
+	}
+
+	d.SetId(primaryEmail)
+	if err = d.Set("asps", result); err != nil {
+		return fmt.Errorf("Error setting asps in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -1,61 +1,146 @@
 package gsuite
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 // userAttrMappings is a mapping of top level keys in a gsuite_user_attributes
-// data source to a struct defining type of of value expected as input and
-// whether or not the value is a list. See also userAttrMapping.schema() which
-// constructs the underlying *schema.Schema at runtime.
+// data source to a struct defining type of of value expected as input, whether
+// or not the value is a list and the schema field type it is encoded as. See
+// also userAttrMapping.schema() which constructs the underlying *schema.Schema
+// at runtime.
 var userAttrMappings = map[string]*userAttrMapping{
-	"string":   {schema.TypeString, false},
-	"strings":  {schema.TypeString, true},
-	"bool":     {schema.TypeBool, false},
-	"bools":    {schema.TypeBool, true},
-	"integer":  {schema.TypeInt, false},
-	"integers": {schema.TypeInt, true},
-	"double":   {schema.TypeFloat, false},
-	"doubles":  {schema.TypeFloat, true},
-	"date":     {schema.TypeString, false},
-	"dates":    {schema.TypeString, true},
-	"email":    {schema.TypeString, false},
-	"emails":   {schema.TypeString, true},
-	"phone":    {schema.TypeString, false},
-	"phones":   {schema.TypeString, true},
+	"string":   {schema.TypeString, false, "STRING"},
+	"strings":  {schema.TypeString, true, "STRING"},
+	"bool":     {schema.TypeBool, false, "BOOL"},
+	"bools":    {schema.TypeBool, true, "BOOL"},
+	"integer":  {schema.TypeInt, false, "INT64"},
+	"integers": {schema.TypeInt, true, "INT64"},
+	"double":   {schema.TypeFloat, false, "DOUBLE"},
+	"doubles":  {schema.TypeFloat, true, "DOUBLE"},
+	"date":     {schema.TypeString, false, "DATE"},
+	"dates":    {schema.TypeString, true, "DATE"},
+	"email":    {schema.TypeString, false, "EMAIL"},
+	"emails":   {schema.TypeString, true, "EMAIL"},
+	"phone":    {schema.TypeString, false, "PHONE"},
+	"phones":   {schema.TypeString, true, "PHONE"},
 }
 
 type userAttrMapping struct {
 	valueType schema.ValueType
 	list      bool
+	fieldType string
 }
 
 func (s *userAttrMapping) schema() *schema.Schema {
-	value := &schema.Schema{Required: true, Type: s.valueType}
+	statement := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"value": {
+			Type:     s.valueType,
+			Required: true,
+		},
+	}
+
 	if s.list {
-		value.Type = schema.TypeList
-		value.Elem = &schema.Schema{Type: s.valueType}
+		// Multi valued fields take either a plain list of values, sharing the
+		// same type, or `entry` blocks with a type per value.
+		statement["value"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: s.valueType},
+		}
+		statement["type"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "work",
+		}
+		statement["custom_type"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+		statement["entry"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:     s.valueType,
+						Required: true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Optional: true,
+						Default:  "work",
+					},
+					"custom_type": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		}
 	}
+
 	return &schema.Schema{
 		Type:     schema.TypeSet,
 		Optional: true,
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-				"name": {
-					Type:     schema.TypeString,
-					Required: true,
-				},
-				"value": value,
-			},
+			Schema: statement,
 		},
 	}
 }
 
+// encode converts a value from the configuration into the JSON type the
+// Directory API expects for the field type.
+func (s *userAttrMapping) encode(value interface{}) (interface{}, error) {
+	switch s.fieldType {
+	case "BOOL":
+		return value.(bool), nil
+	case "INT64":
+		return value.(int), nil
+	case "DOUBLE":
+		// The API does not accept doubles as JSON numbers
+		return strconv.FormatFloat(value.(float64), 'f', -1, 64), nil
+	case "DATE":
+		if _, err := time.Parse("2006-01-02", value.(string)); err != nil {
+			return nil, fmt.Errorf("expected a date formatted as YYYY-MM-DD, got %q", value)
+		}
+		return value.(string), nil
+	default:
+		return value.(string), nil
+	}
+}
+
+type entry struct {
+	Type       string      `json:"type"`
+	CustomType string      `json:"customType,omitempty"`
+	Value      interface{} `json:"value"`
+}
+
+func newEntry(mapping *userAttrMapping, value interface{}, entryType, customType string) (*entry, error) {
+	encoded, err := mapping.encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// A custom type requires the "custom" type
+	if customType != "" {
+		entryType = "custom"
+	}
+
+	return &entry{Type: entryType, CustomType: customType, Value: encoded}, nil
+}
+
 func dataUserAttributes() *schema.Resource {
 	resource := &schema.Resource{
 		Read: dataUserAttributesRead,
@@ -72,55 +157,57 @@ func dataUserAttributes() *schema.Resource {
 	return resource
 }
 
-type entry struct {
-	Type  string      `json:"type"`
-	Value interface{} `json:"value"`
-}
-
-// MarshalJSON converts the interface value to a reasonable string
-// representation. Without this some types of values (basically anything not a string)
-// ends up without quotes around it. While Google's directory SDK does allow you
-// to pass in some types of values without quotes other types, like floats, require
-// strings. Placing quotes around *all* types of values provides the most
-// consistent behavior overall.
-func (e *entry) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf(`{"type": "%s", "value":"%v"}`, e.Type, e.Value)), nil
-}
-
 func dataUserAttributesRead(d *schema.ResourceData, _ interface{}) error {
 	customAttributes := map[string]interface{}{}
 
 	for name, mapping := range userAttrMappings {
-		if mapping.list {
-			if statements, ok := d.GetOk(name); ok {
-				for _, statement := range statements.(*schema.Set).List() {
-					stmt := statement.(map[string]interface{})
-					var values []*entry
-					for _, value := range stmt["value"].([]interface{}) {
-						values = append(values, &entry{Type: "work", Value: value})
-					}
-					customAttributes[stmt["name"].(string)] = values
-				}
-			}
+		statements, ok := d.GetOk(name)
+		if !ok {
 			continue
 		}
 
-		if statements, ok := d.GetOk(name); ok {
-			for _, statement := range statements.(*schema.Set).List() {
-				stmt := statement.(map[string]interface{})
-				customAttributes[stmt["name"].(string)] = stmt["value"]
+		for _, statement := range statements.(*schema.Set).List() {
+			stmt := statement.(map[string]interface{})
+			fieldName := stmt["name"].(string)
+
+			if !mapping.list {
+				value, err := mapping.encode(stmt["value"])
+				if err != nil {
+					return fmt.Errorf("%s %q: %s", name, fieldName, err)
+				}
+				customAttributes[fieldName] = value
+				continue
+			}
+
+			values := []*entry{}
+			for _, value := range stmt["value"].([]interface{}) {
+				e, err := newEntry(mapping, value, stmt["type"].(string), stmt["custom_type"].(string))
+				if err != nil {
+					return fmt.Errorf("%s %q: %s", name, fieldName, err)
+				}
+				values = append(values, e)
+			}
+			for _, rawEntry := range stmt["entry"].([]interface{}) {
+				cfg := rawEntry.(map[string]interface{})
+				e, err := newEntry(mapping, cfg["value"], cfg["type"].(string), cfg["custom_type"].(string))
+				if err != nil {
+					return fmt.Errorf("%s %q: %s", name, fieldName, err)
+				}
+				values = append(values, e)
 			}
+			customAttributes[fieldName] = values
 		}
 	}
 
+	// Map keys are sorted when marshalled, so the output is stable
 	out, err := json.Marshal(customAttributes)
 	if err != nil {
 		return err
 	}
 
-	outString := string(out)
-	d.SetId(strconv.Itoa(hashcode.String(outString)))
-	d.Set("json", outString)
+	sum := sha256.Sum256(out)
+	d.SetId(hex.EncodeToString(sum[:]))
+	d.Set("json", string(out))
 
 	return nil
 }
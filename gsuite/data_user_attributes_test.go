@@ -0,0 +1,69 @@
+package gsuite
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataUserAttributesRead(t *testing.T) {
+	raw := map[string]interface{}{
+		"string": []interface{}{
+			map[string]interface{}{"name": "nickname", "value": `Chase "the" dev`},
+		},
+		"bool": []interface{}{
+			map[string]interface{}{"name": "is_manager", "value": true},
+		},
+		"integer": []interface{}{
+			map[string]interface{}{"name": "level", "value": 3},
+		},
+		"double": []interface{}{
+			map[string]interface{}{"name": "fte", "value": 0.8},
+		},
+		"date": []interface{}{
+			map[string]interface{}{"name": "start_date", "value": "2020-01-31"},
+		},
+		"emails": []interface{}{
+			map[string]interface{}{
+				"name":  "backup",
+				"value": []interface{}{"a@domain.ext"},
+				"entry": []interface{}{
+					map[string]interface{}{"value": "b@domain.ext", "type": "home"},
+					map[string]interface{}{"value": "c@domain.ext", "custom_type": "pager"},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataUserAttributes().Schema, raw)
+	if err := dataUserAttributesRead(d, nil); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	expected := `{"backup":[{"type":"work","value":"a@domain.ext"},{"type":"home","value":"b@domain.ext"},{"type":"custom","customType":"pager","value":"c@domain.ext"}],"fte":"0.8","is_manager":true,"level":3,"nickname":"Chase \"the\" dev","start_date":"2020-01-31"}`
+	if d.Get("json").(string) != expected {
+		t.Errorf("expected json %s, got %s", expected, d.Get("json").(string))
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, dataUserAttributes().Schema, raw)
+	if err := dataUserAttributesRead(d, nil); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if d.Id() != id {
+		t.Errorf("expected a stable id, got %s and %s", id, d.Id())
+	}
+}
+
+func TestDataUserAttributesRead_invalidDate(t *testing.T) {
+	raw := map[string]interface{}{
+		"date": []interface{}{
+			map[string]interface{}{"name": "start_date", "value": "31/01/2020"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataUserAttributes().Schema, raw)
+	if err := dataUserAttributesRead(d, nil); err == nil {
+		t.Errorf("expected an error for an invalid date")
+	}
+}
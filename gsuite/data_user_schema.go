@@ -0,0 +1,106 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// schemaUserSchemaFields describes the `field` blocks of a user schema in the
+// same shape as the gsuite_user_schema resource.
+var schemaUserSchemaFields = &schema.Schema{
+	Type:     schema.TypeList,
+	Computed: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"field_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"field_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"multi_valued": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"read_access_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"indexed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"range": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	},
+}
+
+func dataUserSchema() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserSchemaRead,
+		Schema: map[string]*schema.Schema{
+			"schema_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"schema_name", "schema_id"},
+			},
+
+			"schema_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"field": schemaUserSchemaFields,
+		},
+	}
+}
+
+func dataUserSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	// Schemas.Get accepts both the name and the id
+	schemaKey := d.Get("schema_name").(string)
+	if v, ok := d.GetOk("schema_id"); ok {
+		schemaKey = v.(string)
+	}
+
+	var userSchema *directory.Schema
+	var err error
+	err = retry(func() error {
+		userSchema, err = config.directory.Schemas.Get(config.CustomerId, schemaKey).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching schema %q: %s", schemaKey, err)
+	}
+
+	d.SetId(userSchema.SchemaId)
+	d.Set("schema_id", userSchema.SchemaId)
+	d.Set("schema_name", userSchema.SchemaName)
+	d.Set("display_name", userSchema.DisplayName)
+	if err = d.Set("field", flattenUserSchemaFields(userSchema.Fields)); err != nil {
+		return fmt.Errorf("Error setting field in state: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package gsuite
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUserSchemas() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserSchemasRead,
+		Schema: map[string]*schema.Schema{
+			"schemas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schema_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"field": schemaUserSchemaFields,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataUserSchemasRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var userSchemas *directory.Schemas
+	var err error
+	err = retry(func() error {
+		userSchemas, err = config.directory.Schemas.List(config.CustomerId).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing schemas: %s", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(userSchemas.Schemas))
+	for _, userSchema := range userSchemas.Schemas {
+		result = append(result, map[string]interface{}{
+			"schema_id":    userSchema.SchemaId,
+			"schema_name":  userSchema.SchemaName,
+			"display_name": userSchema.DisplayName,
+			"field":        flattenUserSchemaFields(userSchema.Fields),
+		})
+	}
+
+	d.SetId(config.CustomerId)
+	if err = d.Set("schemas", result); err != nil {
+		return fmt.Errorf("Error setting schemas in state: %s", err.Error())
+	}
+
+	return nil
+}
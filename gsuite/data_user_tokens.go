@@ -0,0 +1,89 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUserTokens() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserTokensRead,
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			"tokens": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_text": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"anonymous": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"native_app": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataUserTokensRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+
+	var tokens *directory.Tokens
+	var err error
+	err = retry(func() error {
+		tokens, err = config.directory.Tokens.List(primaryEmail).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing OAuth tokens of user %s: %s", primaryEmail, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(tokens.Items))
+	for _, token := range tokens.Items {
+		result = append(result, map[string]interface{}{
+			"client_id":    token.ClientId,
+			"display_text": token.DisplayText,
+			"anonymous":    token.Anonymous,
+			"native_app":   token.NativeApp,
+			"scopes":       token.Scopes,
+		})
+	}
+
+	d.SetId(primaryEmail)
+	if err = d.Set("tokens", result); err != nil {
+		return fmt.Errorf("Error setting tokens in state: %s", err.Error())
+	}
+
+	return nil
+}
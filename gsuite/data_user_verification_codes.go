@@ -0,0 +1,59 @@
+package gsuite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataUserVerificationCodes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataUserVerificationCodesRead,
+		Schema: map[string]*schema.Schema{
+			"primary_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+
+			// Backup codes that can still be used to log in
+			"verification_codes": {
+				Type:      schema.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataUserVerificationCodesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+
+	var codes *directory.VerificationCodes
+	var err error
+	err = retry(func() error {
+		codes, err = config.directory.VerificationCodes.List(primaryEmail).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing verification codes of user %s: %s", primaryEmail, err)
+	}
+
+	result := make([]string, 0, len(codes.Items))
+	for _, code := range codes.Items {
+		result = append(result, code.VerificationCode)
+	}
+
+	d.SetId(primaryEmail)
+	d.Set("verification_codes", result)
+
+	return nil
+}
@@ -49,20 +49,47 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"gsuite_group":           dataGroup(),
-			"gsuite_group_settings":  dataGroupSettings(),
-			"gsuite_user":            dataUser(),
-			"gsuite_user_attributes": dataUserAttributes(),
+			"gsuite_chromeos_devices":        dataChromeosDevices(),
+			"gsuite_group":                   dataGroup(),
+			"gsuite_group_settings":          dataGroupSettings(),
+			"gsuite_license_assignments":     dataLicenseAssignments(),
+			"gsuite_mobile_devices":          dataMobileDevices(),
+			"gsuite_user":                    dataUser(),
+			"gsuite_user_asps":               dataUserAsps(),
+			"gsuite_user_attributes":         dataUserAttributes(),
+			"gsuite_user_schema":             dataUserSchema(),
+			"gsuite_user_schemas":            dataUserSchemas(),
+			"gsuite_user_tokens":             dataUserTokens(),
+			"gsuite_user_verification_codes": dataUserVerificationCodes(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"gsuite_domain":          resourceDomain(),
-			"gsuite_group":           resourceGroup(),
-			"gsuite_group_member":    resourceGroupMember(),
-			"gsuite_group_members":   resourceGroupMembers(),
-			"gsuite_group_settings":  resourceGroupSettings(),
-			"gsuite_user":            resourceUser(),
-			"gsuite_user_attributes": resourceUserAttributes(),
-			"gsuite_user_schema":     resourceUserSchema(),
+			"gsuite_calendar":                        resourceCalendar(),
+			"gsuite_calendar_acl":                    resourceCalendarAcl(),
+			"gsuite_chromeos_device":                 resourceChromeosDevice(),
+			"gsuite_cloud_identity_group":            resourceCloudIdentityGroup(),
+			"gsuite_cloud_identity_group_membership": resourceCloudIdentityGroupMembership(),
+			"gsuite_domain":                          resourceDomain(),
+			"gsuite_drive_permission":                resourceDrivePermission(),
+			"gsuite_gmail_auto_forwarding":           resourceGmailAutoForwarding(),
+			"gsuite_gmail_delegate":                  resourceGmailDelegate(),
+			"gsuite_gmail_filter":                    resourceGmailFilter(),
+			"gsuite_gmail_forwarding_address":        resourceGmailForwardingAddress(),
+			"gsuite_gmail_imap":                      resourceGmailImap(),
+			"gsuite_gmail_label":                     resourceGmailLabel(),
+			"gsuite_gmail_pop":                       resourceGmailPop(),
+			"gsuite_gmail_send_as":                   resourceGmailSendAs(),
+			"gsuite_gmail_signature":                 resourceGmailSignature(),
+			"gsuite_gmail_vacation":                  resourceGmailVacation(),
+			"gsuite_group":                           resourceGroup(),
+			"gsuite_group_member":                    resourceGroupMember(),
+			"gsuite_group_members":                   resourceGroupMembers(),
+			"gsuite_group_settings":                  resourceGroupSettings(),
+			"gsuite_shared_drive":                    resourceSharedDrive(),
+			"gsuite_user":                            resourceUser(),
+			"gsuite_user_attributes":                 resourceUserAttributes(),
+			"gsuite_user_license":                    resourceUserLicense(),
+			"gsuite_user_schema":                     resourceUserSchema(),
+			"gsuite_users_attributes":                resourceUsersAttributes(),
 		},
 	}
 
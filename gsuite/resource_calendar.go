@@ -0,0 +1,184 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/calendar/v3"
+)
+
+// Secondary calendar owned by a user
+func resourceCalendar() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCalendarCreate,
+		Read:   resourceCalendarRead,
+		Update: resourceCalendarUpdate,
+		Delete: resourceCalendarDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCalendarImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// The user owning the calendar, the API is called as this user
+			"owner": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			"summary": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// IANA time zone, e.g. Europe/Amsterdam, defaults to the one of the owner
+			"time_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"calendar_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandCalendar(d *schema.ResourceData) *calendar.Calendar {
+	return &calendar.Calendar{
+		Summary:         d.Get("summary").(string),
+		Description:     d.Get("description").(string),
+		Location:        d.Get("location").(string),
+		TimeZone:        d.Get("time_zone").(string),
+		ForceSendFields: []string{"Description", "Location"},
+	}
+}
+
+func resourceCalendarCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	owner := strings.ToLower(d.Get("owner").(string))
+	calendarSvc, err := config.calendarService(owner)
+	if err != nil {
+		return err
+	}
+
+	var created *calendar.Calendar
+	err = retry(func() error {
+		created, err = calendarSvc.Calendars.Insert(expandCalendar(d)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating calendar: %s", err)
+	}
+
+	d.SetId(created.Id)
+	log.Printf("[INFO] Created calendar %s for %s", created.Summary, owner)
+
+	return resourceCalendarRead(d, meta)
+}
+
+func resourceCalendarUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarSvc, err := config.calendarService(strings.ToLower(d.Get("owner").(string)))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating calendar %s", d.Id())
+	err = retry(func() error {
+		_, err = calendarSvc.Calendars.Patch(d.Id(), expandCalendar(d)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating calendar: %s", err)
+	}
+
+	return resourceCalendarRead(d, meta)
+}
+
+func resourceCalendarRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarSvc, err := config.calendarService(strings.ToLower(d.Get("owner").(string)))
+	if err != nil {
+		return err
+	}
+
+	var cal *calendar.Calendar
+	err = retry(func() error {
+		cal, err = calendarSvc.Calendars.Get(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Calendar %q", d.Id()))
+	}
+
+	d.Set("calendar_id", cal.Id)
+	d.Set("summary", cal.Summary)
+	d.Set("description", cal.Description)
+	d.Set("location", cal.Location)
+	d.Set("time_zone", cal.TimeZone)
+	d.Set("etag", cal.Etag)
+
+	return nil
+}
+
+func resourceCalendarDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarSvc, err := config.calendarService(strings.ToLower(d.Get("owner").(string)))
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return calendarSvc.Calendars.Delete(d.Id()).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting calendar: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [owner email]/[calendar id]
+func resourceCalendarImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [owner email]/[calendar id]")
+	}
+
+	d.SetId(s[1])
+	d.Set("owner", strings.ToLower(s[0]))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,255 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/calendar/v3"
+)
+
+func resourceCalendarAcl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCalendarAclCreate,
+		Read:   resourceCalendarAclRead,
+		Update: resourceCalendarAclUpdate,
+		Delete: resourceCalendarAclDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCalendarAclImporter,
+		},
+
+		CustomizeDiff: resourceCalendarAclCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			// The id of a calendar, for the primary calendar of a user its email
+			"calendar_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The user the API is called as, see calendarActingUser
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"none", "freeBusyReader", "reader", "writer", "owner"}, false),
+			},
+
+			"scope_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"default", "user", "group", "domain"}, false),
+			},
+
+			// Email of the user or group, or the domain name; empty for default
+			"scope_value": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+			},
+
+			"send_notifications": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"rule_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCalendarAclCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("scope_type") || !d.NewValueKnown("scope_value") {
+		return nil
+	}
+
+	scopeType, scopeValue := d.Get("scope_type").(string), d.Get("scope_value").(string)
+	if scopeType == "default" && scopeValue != "" {
+		return fmt.Errorf("scope_value can't be set when scope_type is default")
+	}
+	if scopeType != "default" && scopeValue == "" {
+		return fmt.Errorf("scope_value is required when scope_type is %s", scopeType)
+	}
+	return nil
+}
+
+// calendarActingUser returns the user to call the API as: the owner when set,
+// the user of a primary calendar, or impersonated_user_email for the other
+// calendars (e.g. of groups and resources), which administrators can manage.
+func calendarActingUser(config *Config, owner, calendarID string) string {
+	if owner != "" {
+		return strings.ToLower(owner)
+	}
+	if strings.Contains(calendarID, "@") && !strings.HasSuffix(calendarID, ".calendar.google.com") {
+		return strings.ToLower(calendarID)
+	}
+	return config.ImpersonatedUserEmail
+}
+
+func calendarAclService(d *schema.ResourceData, config *Config) (*calendar.Service, error) {
+	return config.calendarService(calendarActingUser(config, d.Get("owner").(string), d.Get("calendar_id").(string)))
+}
+
+func resourceCalendarAclCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarSvc, err := calendarAclService(d, config)
+	if err != nil {
+		return err
+	}
+
+	calendarID := d.Get("calendar_id").(string)
+	rule := &calendar.AclRule{
+		Role: d.Get("role").(string),
+		Scope: &calendar.AclRuleScope{
+			Type:  d.Get("scope_type").(string),
+			Value: strings.ToLower(d.Get("scope_value").(string)),
+		},
+	}
+
+	var created *calendar.AclRule
+	err = retry(func() error {
+		created, err = calendarSvc.Acl.Insert(calendarID, rule).SendNotifications(d.Get("send_notifications").(bool)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating calendar ACL rule: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", calendarID, created.Id))
+	log.Printf("[INFO] Created calendar ACL rule %s on %s", created.Id, calendarID)
+
+	return resourceCalendarAclRead(d, meta)
+}
+
+func resourceCalendarAclUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if !d.HasChange("role") {
+		return resourceCalendarAclRead(d, meta)
+	}
+
+	calendarSvc, err := calendarAclService(d, config)
+	if err != nil {
+		return err
+	}
+
+	rule := &calendar.AclRule{
+		Role: d.Get("role").(string),
+	}
+
+	log.Printf("[DEBUG] Updating calendar ACL rule %s", d.Id())
+	err = retry(func() error {
+		_, err = calendarSvc.Acl.Patch(d.Get("calendar_id").(string), d.Get("rule_id").(string), rule).
+			SendNotifications(d.Get("send_notifications").(bool)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating calendar ACL rule: %s", err)
+	}
+
+	return resourceCalendarAclRead(d, meta)
+}
+
+func resourceCalendarAclRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarSvc, err := calendarAclService(d, config)
+	if err != nil {
+		return err
+	}
+
+	calendarID, ruleID := calendarAclSplitId(d.Id())
+
+	var rule *calendar.AclRule
+	err = retry(func() error {
+		rule, err = calendarSvc.Acl.Get(calendarID, ruleID).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Calendar ACL rule %q", d.Id()))
+	}
+
+	d.Set("calendar_id", calendarID)
+	d.Set("rule_id", rule.Id)
+	d.Set("role", rule.Role)
+	if rule.Scope != nil {
+		d.Set("scope_type", rule.Scope.Type)
+		d.Set("scope_value", strings.ToLower(rule.Scope.Value))
+	}
+	d.Set("etag", rule.Etag)
+
+	return nil
+}
+
+func resourceCalendarAclDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	calendarSvc, err := calendarAclService(d, config)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return calendarSvc.Acl.Delete(d.Get("calendar_id").(string), d.Get("rule_id").(string)).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting calendar ACL rule: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// calendarAclSplitId splits [calendar id]/[rule id], rule ids never contain a slash.
+func calendarAclSplitId(id string) (string, string) {
+	i := strings.LastIndex(id, "/")
+	if i < 0 {
+		return id, ""
+	}
+	return id[:i], id[i+1:]
+}
+
+// Allow importing using [calendar id]/[rule id], e.g. team@domain.ext/user:john@domain.ext
+func resourceCalendarAclImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	calendarID, ruleID := calendarAclSplitId(d.Id())
+	if calendarID == "" || ruleID == "" {
+		return nil, fmt.Errorf("[WARN] Import via [calendar id]/[rule id]")
+	}
+
+	d.Set("calendar_id", calendarID)
+	d.Set("rule_id", ruleID)
+	d.Set("send_notifications", false)
+
+	return []*schema.ResourceData{d}, nil
+}
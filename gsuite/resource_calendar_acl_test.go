@@ -0,0 +1,38 @@
+package gsuite
+
+import (
+	"testing"
+)
+
+func TestCalendarAclSplitId(t *testing.T) {
+	cases := map[string][2]string{
+		"team@domain.ext/user:john@domain.ext":              {"team@domain.ext", "user:john@domain.ext"},
+		"c_123@group.calendar.google.com/domain:domain.ext": {"c_123@group.calendar.google.com", "domain:domain.ext"},
+		"room@resource.calendar.google.com/default":         {"room@resource.calendar.google.com", "default"},
+		"team@domain.ext": {"team@domain.ext", ""},
+	}
+	for id, expected := range cases {
+		calendarID, ruleID := calendarAclSplitId(id)
+		if calendarID != expected[0] || ruleID != expected[1] {
+			t.Errorf("calendarAclSplitId(%q): expected %v, got [%s %s]", id, expected, calendarID, ruleID)
+		}
+	}
+}
+
+func TestCalendarActingUser(t *testing.T) {
+	config := &Config{ImpersonatedUserEmail: "admin@domain.ext"}
+
+	cases := []struct {
+		owner, calendarID, expected string
+	}{
+		{"Owner@domain.ext", "c_123@group.calendar.google.com", "owner@domain.ext"},
+		{"", "John@domain.ext", "john@domain.ext"},
+		{"", "c_123@group.calendar.google.com", "admin@domain.ext"},
+		{"", "room@resource.calendar.google.com", "admin@domain.ext"},
+	}
+	for _, c := range cases {
+		if user := calendarActingUser(config, c.owner, c.calendarID); user != c.expected {
+			t.Errorf("calendarActingUser(%q, %q): expected %s, got %s", c.owner, c.calendarID, c.expected, user)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// Enrolled ChromeOS device, devices can't be created through the API, the
+// resource manages an existing device
+func resourceChromeosDevice() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceChromeosDeviceCreate,
+		Read:   resourceChromeosDeviceRead,
+		Update: resourceChromeosDeviceUpdate,
+		Delete: resourceChromeosDeviceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceChromeosDeviceImporter,
+		},
+
+		CustomizeDiff: resourceChromeosDeviceCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Annotations left unset, or set to "", are cleared
+			"annotated_user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"annotated_location": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"annotated_asset_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"org_unit_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			// Disables or reenables the device
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Deprovisioning can't be undone, the device has to be enrolled again
+			"deprovision_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"deprovision_reason": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"same_model_replacement",
+					"different_model_replacement",
+					"retiring_device",
+					"upgrade_transfer",
+				}, false),
+			},
+
+			"serial_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"model": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"os_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_sync": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceChromeosDeviceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("deprovision_on_destroy") || !d.NewValueKnown("deprovision_reason") {
+		return nil
+	}
+
+	if d.Get("deprovision_on_destroy").(bool) && d.Get("deprovision_reason").(string) == "" {
+		return fmt.Errorf("deprovision_reason is required when deprovision_on_destroy is true")
+	}
+	return nil
+}
+
+// chromeosDeviceAction returns the action to take to reach the disabled
+// state, or an empty string when the device is already in that state.
+func chromeosDeviceAction(status string, disabled bool) string {
+	if disabled && status != "DISABLED" {
+		return "disable"
+	}
+	if !disabled && status == "DISABLED" {
+		return "reenable"
+	}
+	return ""
+}
+
+// expandChromeosDeviceAnnotations returns the annotations which differ from
+// those of the device, annotations which are not configured are cleared.
+func expandChromeosDeviceAnnotations(d *schema.ResourceData, device *directory.ChromeOsDevice) (*directory.ChromeOsDevice, bool) {
+	annotations := &directory.ChromeOsDevice{}
+	fields := reflect.ValueOf(annotations).Elem()
+	current := reflect.ValueOf(device).Elem()
+	changed := false
+	for key, field := range map[string]string{
+		"annotated_user":     "AnnotatedUser",
+		"annotated_location": "AnnotatedLocation",
+		"annotated_asset_id": "AnnotatedAssetId",
+		"notes":              "Notes",
+	} {
+		value := d.Get(key).(string)
+		if value == current.FieldByName(field).String() {
+			continue
+		}
+		fields.FieldByName(field).SetString(value)
+		if value == "" {
+			annotations.ForceSendFields = append(annotations.ForceSendFields, field)
+		}
+		changed = true
+	}
+	return annotations, changed
+}
+
+func chromeosDeviceRunAction(config *Config, deviceID string, action *directory.ChromeOsDeviceAction) error {
+	log.Printf("[DEBUG] Running action %s on ChromeOS device %s", action.Action, deviceID)
+	err := retry(func() error {
+		return config.directory.Chromeosdevices.Action(config.CustomerId, deviceID, action).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error running action %s on ChromeOS device %s: %s", action.Action, deviceID, err)
+	}
+	return nil
+}
+
+// chromeosDeviceGetManageable fetches a device, a deprovisioned device can't
+// be managed until it is enrolled again.
+func chromeosDeviceGetManageable(config *Config, deviceID string) (*directory.ChromeOsDevice, error) {
+	var device *directory.ChromeOsDevice
+	var err error
+	err = retry(func() error {
+		device, err = config.directory.Chromeosdevices.Get(config.CustomerId, deviceID).Projection("BASIC").Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error fetching ChromeOS device %s: %s", deviceID, err)
+	}
+
+	if device.Status == "DEPROVISIONED" {
+		return nil, fmt.Errorf("[ERROR] ChromeOS device %s is deprovisioned, it has to be enrolled again", deviceID)
+	}
+	return device, nil
+}
+
+func resourceChromeosDeviceCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	// Checked before the device is in the state
+	if _, err := chromeosDeviceGetManageable(config, d.Get("device_id").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("device_id").(string))
+
+	log.Printf("[INFO] Managing ChromeOS device %s", d.Id())
+	return resourceChromeosDeviceUpdate(d, meta)
+}
+
+func resourceChromeosDeviceUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	device, err := chromeosDeviceGetManageable(config, d.Id())
+	if err != nil {
+		return err
+	}
+
+	if annotations, changed := expandChromeosDeviceAnnotations(d, device); changed {
+		log.Printf("[DEBUG] Updating annotations of ChromeOS device %s", d.Id())
+		err = retry(func() error {
+			_, err = config.directory.Chromeosdevices.Patch(config.CustomerId, d.Id(), annotations).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error updating ChromeOS device %s: %s", d.Id(), err)
+		}
+	}
+
+	if orgUnitPath, ok := d.GetOk("org_unit_path"); ok && orgUnitPath.(string) != device.OrgUnitPath {
+		log.Printf("[DEBUG] Moving ChromeOS device %s to %s", d.Id(), orgUnitPath)
+		err = retry(func() error {
+			return config.directory.Chromeosdevices.MoveDevicesToOu(config.CustomerId, orgUnitPath.(string),
+				&directory.ChromeOsMoveDevicesToOu{DeviceIds: []string{d.Id()}}).Do()
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error moving ChromeOS device %s to %s: %s", d.Id(), orgUnitPath, err)
+		}
+	}
+
+	if action := chromeosDeviceAction(device.Status, d.Get("disabled").(bool)); action != "" {
+		if err = chromeosDeviceRunAction(config, d.Id(), &directory.ChromeOsDeviceAction{Action: action}); err != nil {
+			return err
+		}
+	}
+
+	return resourceChromeosDeviceRead(d, meta)
+}
+
+func resourceChromeosDeviceRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var device *directory.ChromeOsDevice
+	var err error
+	err = retry(func() error {
+		device, err = config.directory.Chromeosdevices.Get(config.CustomerId, d.Id()).Projection("BASIC").Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("ChromeOS device %q", d.Id()))
+	}
+
+	// A deprovisioned device has to be enrolled again
+	if device.Status == "DEPROVISIONED" {
+		log.Printf("[WARN] ChromeOS device %s is deprovisioned, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("device_id", device.DeviceId)
+	d.Set("annotated_user", device.AnnotatedUser)
+	d.Set("annotated_location", device.AnnotatedLocation)
+	d.Set("annotated_asset_id", device.AnnotatedAssetId)
+	d.Set("notes", device.Notes)
+	d.Set("org_unit_path", device.OrgUnitPath)
+	d.Set("disabled", device.Status == "DISABLED")
+	d.Set("serial_number", device.SerialNumber)
+	d.Set("model", device.Model)
+	d.Set("status", device.Status)
+	d.Set("os_version", device.OsVersion)
+	d.Set("last_sync", device.LastSync)
+	d.Set("etag", device.Etag)
+
+	return nil
+}
+
+// Deprovisions the device when deprovision_on_destroy is set, otherwise the
+// device is only removed from the state
+func resourceChromeosDeviceDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.Get("deprovision_on_destroy").(bool) {
+		action := &directory.ChromeOsDeviceAction{
+			Action:            "deprovision",
+			DeprovisionReason: d.Get("deprovision_reason").(string),
+		}
+		if err := chromeosDeviceRunAction(config, d.Id(), action); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[WARN] ChromeOS device %s is only removed from the state", d.Id())
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceChromeosDeviceImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("device_id", d.Id())
+	d.Set("deprovision_on_destroy", false)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,112 @@
+package gsuite
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestChromeosDeviceAction(t *testing.T) {
+	cases := []struct {
+		status   string
+		disabled bool
+		expected string
+	}{
+		{"ACTIVE", false, ""},
+		{"ACTIVE", true, "disable"},
+		{"DISABLED", true, ""},
+		{"DISABLED", false, "reenable"},
+		{"PROVISIONED", true, "disable"},
+	}
+	for _, c := range cases {
+		if action := chromeosDeviceAction(c.status, c.disabled); action != c.expected {
+			t.Errorf("chromeosDeviceAction(%q, %t): expected %q, got %q", c.status, c.disabled, c.expected, action)
+		}
+	}
+}
+
+func TestExpandChromeosDeviceAnnotations(t *testing.T) {
+	r := resourceChromeosDevice()
+	device := &directory.ChromeOsDevice{
+		AnnotatedUser:     "john@domain.ext",
+		AnnotatedLocation: "Office",
+		AnnotatedAssetId:  "LAPTOP-0042",
+		Notes:             "Spare",
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"device_id":          "123",
+		"annotated_user":     "",
+		"annotated_location": "Home",
+		"annotated_asset_id": "LAPTOP-0042",
+	})
+	annotations, changed := expandChromeosDeviceAnnotations(d, device)
+	if !changed {
+		t.Fatalf("expected changed annotations")
+	}
+	if annotations.AnnotatedLocation != "Home" || annotations.AnnotatedAssetId != "" {
+		t.Errorf("expected only annotated_location to be set, got %#v", annotations)
+	}
+	sort.Strings(annotations.ForceSendFields)
+	if !reflect.DeepEqual(annotations.ForceSendFields, []string{"AnnotatedUser", "Notes"}) {
+		t.Errorf("expected annotated_user and notes to be cleared, got %v", annotations.ForceSendFields)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"device_id":          "123",
+		"annotated_user":     "john@domain.ext",
+		"annotated_location": "Office",
+		"annotated_asset_id": "LAPTOP-0042",
+		"notes":              "Spare",
+	})
+	if _, changed := expandChromeosDeviceAnnotations(d, device); changed {
+		t.Errorf("expected no changed annotations")
+	}
+}
+
+func TestFlattenChromeosDevices(t *testing.T) {
+	devices := []*directory.ChromeOsDevice{
+		{
+			DeviceId:      "device-1",
+			SerialNumber:  "SN1",
+			Status:        "ACTIVE",
+			OrgUnitPath:   "/Devices",
+			AnnotatedUser: "john@domain.ext",
+			RecentUsers: []*directory.RecentUsers{
+				{Email: "john@domain.ext", Type: "USER_TYPE_MANAGED"},
+				{Type: "USER_TYPE_UNMANAGED"},
+			},
+		},
+	}
+
+	result := flattenChromeosDevices(devices)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 device, got %d", len(result))
+	}
+	if result[0]["device_id"] != "device-1" || result[0]["org_unit_path"] != "/Devices" {
+		t.Errorf("unexpected device %v", result[0])
+	}
+	if !reflect.DeepEqual(result[0]["recent_users"], []string{"john@domain.ext"}) {
+		t.Errorf("expected only the managed recent user, got %v", result[0]["recent_users"])
+	}
+}
+
+func TestFlattenMobileDevices(t *testing.T) {
+	devices := []*directory.MobileDevice{
+		{ResourceId: "resource-1", Email: []string{"john@domain.ext"}, Os: "Android 12", Status: "APPROVED"},
+	}
+
+	result := flattenMobileDevices(devices)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 device, got %d", len(result))
+	}
+	if result[0]["resource_id"] != "resource-1" || result[0]["status"] != "APPROVED" {
+		t.Errorf("unexpected device %v", result[0])
+	}
+	if !reflect.DeepEqual(result[0]["email"], []string{"john@domain.ext"}) {
+		t.Errorf("unexpected emails %v", result[0]["email"])
+	}
+}
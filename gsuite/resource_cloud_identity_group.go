@@ -0,0 +1,412 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/cloudidentity/v1"
+)
+
+// cloudIdentityGroupLabels maps the labels of the resource on the label keys
+// of the Cloud Identity API.
+var cloudIdentityGroupLabels = map[string]string{
+	"discussion_forum": "cloudidentity.googleapis.com/groups.discussion_forum",
+	"security":         "cloudidentity.googleapis.com/groups.security",
+}
+
+// The label of dynamic groups, set whenever dynamic_group_metadata is set
+const cloudIdentityDynamicGroupLabel = "cloudidentity.googleapis.com/groups.dynamic"
+
+func resourceCloudIdentityGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudIdentityGroupCreate,
+		Read:   resourceCloudIdentityGroupRead,
+		Update: resourceCloudIdentityGroupUpdate,
+		Delete: resourceCloudIdentityGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCloudIdentityGroupImporter,
+		},
+
+		CustomizeDiff: resourceCloudIdentityGroupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			// customers/{customer_id}, defaults to the customer of the
+			// impersonated user
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"discussion_forum", "security"}, false),
+				},
+			},
+
+			"initial_group_config": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "EMPTY",
+				ValidateFunc: validation.StringInSlice([]string{"EMPTY", "WITH_INITIAL_OWNER"}, false),
+			},
+
+			"dynamic_group_metadata": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"resource_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "USER",
+									},
+									"query": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			// groups/{group_id}
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"update_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// The security label can't be removed, and groups can't be converted from
+// or to dynamic groups.
+func resourceCloudIdentityGroupCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("labels") {
+		o, n := d.GetChange("labels")
+		if o.(*schema.Set).Contains("security") && !n.(*schema.Set).Contains("security") {
+			if err := d.ForceNew("labels"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("dynamic_group_metadata") {
+		o, n := d.GetChange("dynamic_group_metadata")
+		if (len(o.([]interface{})) == 0) != (len(n.([]interface{})) == 0) {
+			if err := d.ForceNew("dynamic_group_metadata"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandCloudIdentityGroupLabels(d *schema.ResourceData) map[string]string {
+	labels := map[string]string{}
+	for _, label := range convertStringSet(d.Get("labels").(*schema.Set)) {
+		labels[cloudIdentityGroupLabels[label]] = ""
+	}
+	if len(d.Get("dynamic_group_metadata").([]interface{})) > 0 {
+		labels[cloudIdentityDynamicGroupLabel] = ""
+	}
+	return labels
+}
+
+func expandCloudIdentityDynamicGroupMetadata(d *schema.ResourceData) *cloudidentity.DynamicGroupMetadata {
+	metadata := d.Get("dynamic_group_metadata").([]interface{})
+	if len(metadata) == 0 || metadata[0] == nil {
+		return nil
+	}
+
+	queries := []*cloudidentity.DynamicGroupQuery{}
+	for _, q := range metadata[0].(map[string]interface{})["query"].([]interface{}) {
+		query := q.(map[string]interface{})
+		queries = append(queries, &cloudidentity.DynamicGroupQuery{
+			ResourceType: query["resource_type"].(string),
+			Query:        query["query"].(string),
+		})
+	}
+
+	return &cloudidentity.DynamicGroupMetadata{Queries: queries}
+}
+
+func flattenCloudIdentityDynamicGroupMetadata(metadata *cloudidentity.DynamicGroupMetadata) []map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+
+	queries := []map[string]interface{}{}
+	for _, query := range metadata.Queries {
+		queries = append(queries, map[string]interface{}{
+			"resource_type": query.ResourceType,
+			"query":         query.Query,
+		})
+	}
+
+	flattened := map[string]interface{}{
+		"query": queries,
+	}
+	if metadata.Status != nil {
+		flattened["status"] = metadata.Status.Status
+		flattened["status_time"] = metadata.Status.StatusTime
+	}
+
+	return []map[string]interface{}{flattened}
+}
+
+// cloudIdentityParent returns the parent of groups of the configured
+// customer, the Cloud Identity API does not accept "my_customer".
+func cloudIdentityParent(config *Config) (string, error) {
+	customerID, err := config.resolveCustomerId()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("customers/%s", customerID), nil
+}
+
+// cloudIdentityOperationError returns the error of a failed long running
+// operation.
+func cloudIdentityOperationError(op *cloudidentity.Operation) error {
+	if op != nil && op.Error != nil {
+		return fmt.Errorf("%s (code %d)", op.Error.Message, op.Error.Code)
+	}
+	return nil
+}
+
+func resourceCloudIdentityGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	email := strings.ToLower(d.Get("email").(string))
+
+	parent := d.Get("parent").(string)
+	if parent == "" {
+		var err error
+		if parent, err = cloudIdentityParent(config); err != nil {
+			return err
+		}
+	}
+
+	group := &cloudidentity.Group{
+		GroupKey:             &cloudidentity.EntityKey{Id: email},
+		Parent:               parent,
+		DisplayName:          d.Get("display_name").(string),
+		Description:          d.Get("description").(string),
+		Labels:               expandCloudIdentityGroupLabels(d),
+		DynamicGroupMetadata: expandCloudIdentityDynamicGroupMetadata(d),
+	}
+
+	var op *cloudidentity.Operation
+	var err error
+	err = retry(func() error {
+		op, err = config.cloudIdentity.Groups.Create(group).InitialGroupConfig(d.Get("initial_group_config").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err == nil {
+		err = cloudIdentityOperationError(op)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating group: %s", err)
+	}
+
+	// Look up the name of the group, retrying for 404's until the group
+	// exists
+	var lookup *cloudidentity.LookupGroupNameResponse
+	err = retryNotFound(func() error {
+		lookup, err = config.cloudIdentity.Groups.Lookup().GroupKeyId(email).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Taking too long to create this group: %s", err)
+	}
+
+	d.SetId(lookup.Name)
+	log.Printf("[INFO] Created group: %s", email)
+
+	return resourceCloudIdentityGroupRead(d, meta)
+}
+
+func resourceCloudIdentityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	group := &cloudidentity.Group{}
+	updateMask := []string{}
+
+	if d.HasChange("display_name") {
+		log.Printf("[DEBUG] Updating group display_name: %s", d.Get("display_name").(string))
+		group.DisplayName = d.Get("display_name").(string)
+		updateMask = append(updateMask, "display_name")
+	}
+
+	if d.HasChange("description") {
+		log.Printf("[DEBUG] Updating group description: %s", d.Get("description").(string))
+		group.Description = d.Get("description").(string)
+		updateMask = append(updateMask, "description")
+	}
+
+	if d.HasChange("labels") {
+		group.Labels = expandCloudIdentityGroupLabels(d)
+		log.Printf("[DEBUG] Updating group labels: %v", group.Labels)
+		updateMask = append(updateMask, "labels")
+	}
+
+	if d.HasChange("dynamic_group_metadata") {
+		group.DynamicGroupMetadata = expandCloudIdentityDynamicGroupMetadata(d)
+		log.Printf("[DEBUG] Updating group dynamic_group_metadata")
+		updateMask = append(updateMask, "dynamic_group_metadata")
+	}
+
+	if len(updateMask) > 0 {
+		var op *cloudidentity.Operation
+		var err error
+		err = retry(func() error {
+			op, err = config.cloudIdentity.Groups.Patch(d.Id(), group).UpdateMask(strings.Join(updateMask, ",")).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err == nil {
+			err = cloudIdentityOperationError(op)
+		}
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error updating group: %s", err)
+		}
+
+		log.Printf("[INFO] Updated group: %s", d.Get("email").(string))
+	}
+
+	return resourceCloudIdentityGroupRead(d, meta)
+}
+
+func resourceCloudIdentityGroupRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var group *cloudidentity.Group
+	var err error
+	err = retry(func() error {
+		group, err = config.cloudIdentity.Groups.Get(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Group %q", d.Get("email").(string)))
+	}
+
+	labels := []string{}
+	for label, key := range cloudIdentityGroupLabels {
+		if _, ok := group.Labels[key]; ok {
+			labels = append(labels, label)
+		}
+	}
+
+	d.SetId(group.Name)
+	d.Set("name", group.Name)
+	if group.GroupKey != nil {
+		d.Set("email", strings.ToLower(group.GroupKey.Id))
+	}
+	d.Set("parent", group.Parent)
+	d.Set("display_name", group.DisplayName)
+	d.Set("description", group.Description)
+	d.Set("labels", labels)
+	d.Set("dynamic_group_metadata", flattenCloudIdentityDynamicGroupMetadata(group.DynamicGroupMetadata))
+	d.Set("create_time", group.CreateTime)
+	d.Set("update_time", group.UpdateTime)
+
+	return nil
+}
+
+func resourceCloudIdentityGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var op *cloudidentity.Operation
+	var err error
+	err = retry(func() error {
+		op, err = config.cloudIdentity.Groups.Delete(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err == nil {
+		err = cloudIdentityOperationError(op)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting group: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using the name (groups/{group_id}) or the email
+func resourceCloudIdentityGroupImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+
+	if !strings.HasPrefix(d.Id(), "groups/") {
+		lookup, err := config.cloudIdentity.Groups.Lookup().GroupKeyId(strings.ToLower(d.Id())).Do()
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error fetching group. Make sure the group exists: %s ", err)
+		}
+		d.SetId(lookup.Name)
+	}
+
+	// Groups which existed before are not created with an initial config
+	d.Set("initial_group_config", "EMPTY")
+
+	return []*schema.ResourceData{d}, nil
+}
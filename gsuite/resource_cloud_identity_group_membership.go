@@ -0,0 +1,282 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/cloudidentity/v1"
+)
+
+func resourceCloudIdentityGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudIdentityGroupMembershipCreate,
+		Read:   resourceCloudIdentityGroupMembershipRead,
+		Update: resourceCloudIdentityGroupMembershipUpdate,
+		Delete: resourceCloudIdentityGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCloudIdentityGroupMembershipImporter,
+		},
+
+		CustomizeDiff: resourceCloudIdentityGroupMembershipCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			// groups/{group_id}
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			"roles": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"MEMBER", "MANAGER", "OWNER"}, false),
+				},
+			},
+
+			// Expiry of the MEMBER role, the API returns it in UTC
+			"expire_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+			},
+
+			// groups/{group_id}/memberships/{membership_id}
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"update_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Every membership has the MEMBER role, only that role can expire.
+func resourceCloudIdentityGroupMembershipCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("roles") {
+		return nil
+	}
+
+	roles := d.Get("roles").(*schema.Set)
+	if !roles.Contains("MEMBER") {
+		return fmt.Errorf("roles must contain MEMBER")
+	}
+	if d.Get("expire_time").(string) != "" && roles.Len() > 1 {
+		return fmt.Errorf("expire_time can only be set on memberships with only the MEMBER role")
+	}
+
+	return nil
+}
+
+func expandCloudIdentityMembershipRole(d *schema.ResourceData, role string) *cloudidentity.MembershipRole {
+	membershipRole := &cloudidentity.MembershipRole{Name: role}
+	if expireTime := d.Get("expire_time").(string); role == "MEMBER" && expireTime != "" {
+		membershipRole.ExpiryDetail = &cloudidentity.ExpiryDetail{ExpireTime: expireTime}
+	}
+	return membershipRole
+}
+
+func resourceCloudIdentityGroupMembershipCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	group := d.Get("group").(string)
+	email := strings.ToLower(d.Get("email").(string))
+
+	membership := &cloudidentity.Membership{
+		PreferredMemberKey: &cloudidentity.EntityKey{Id: email},
+	}
+	for _, role := range convertStringSet(d.Get("roles").(*schema.Set)) {
+		membership.Roles = append(membership.Roles, expandCloudIdentityMembershipRole(d, role))
+	}
+
+	var op *cloudidentity.Operation
+	var err error
+	err = retryPassDuplicate(func() error {
+		op, err = config.cloudIdentity.Groups.Memberships.Create(group, membership).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err == nil {
+		err = cloudIdentityOperationError(op)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating group membership: %s", err)
+	}
+
+	// Look up the name of the membership, retrying for 404's until it exists
+	var lookup *cloudidentity.LookupMembershipNameResponse
+	err = retryNotFound(func() error {
+		lookup, err = config.cloudIdentity.Groups.Memberships.Lookup(group).MemberKeyId(email).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Taking too long to create this group membership: %s", err)
+	}
+
+	d.SetId(lookup.Name)
+	log.Printf("[INFO] Created group membership: %s", email)
+
+	return resourceCloudIdentityGroupMembershipRead(d, meta)
+}
+
+func resourceCloudIdentityGroupMembershipUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("roles") {
+		o, n := d.GetChange("roles")
+		request := &cloudidentity.ModifyMembershipRolesRequest{
+			RemoveRoles: convertStringSet(o.(*schema.Set).Difference(n.(*schema.Set))),
+		}
+		for _, role := range convertStringSet(n.(*schema.Set).Difference(o.(*schema.Set))) {
+			request.AddRoles = append(request.AddRoles, expandCloudIdentityMembershipRole(d, role))
+		}
+
+		log.Printf("[DEBUG] Updating group membership roles: %v", convertStringSet(n.(*schema.Set)))
+		if err := cloudIdentityModifyMembershipRoles(config, d.Id(), request); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("expire_time") {
+		log.Printf("[DEBUG] Updating group membership expire_time: %s", d.Get("expire_time").(string))
+		role := expandCloudIdentityMembershipRole(d, "MEMBER")
+		if role.ExpiryDetail == nil {
+			role.ExpiryDetail = &cloudidentity.ExpiryDetail{}
+		}
+		request := &cloudidentity.ModifyMembershipRolesRequest{
+			UpdateRolesParams: []*cloudidentity.UpdateMembershipRolesParams{
+				{
+					FieldMask:      "expiry_detail.expire_time",
+					MembershipRole: role,
+				},
+			},
+		}
+		if err := cloudIdentityModifyMembershipRoles(config, d.Id(), request); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudIdentityGroupMembershipRead(d, meta)
+}
+
+func cloudIdentityModifyMembershipRoles(config *Config, name string, request *cloudidentity.ModifyMembershipRolesRequest) error {
+	var err error
+	err = retry(func() error {
+		_, err = config.cloudIdentity.Groups.Memberships.ModifyMembershipRoles(name, request).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating group membership: %s", err)
+	}
+	return nil
+}
+
+func resourceCloudIdentityGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var membership *cloudidentity.Membership
+	var err error
+	err = retry(func() error {
+		membership, err = config.cloudIdentity.Groups.Memberships.Get(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Group membership %q", d.Get("email").(string)))
+	}
+
+	roles := []string{}
+	expireTime := ""
+	for _, role := range membership.Roles {
+		roles = append(roles, role.Name)
+		if role.Name == "MEMBER" && role.ExpiryDetail != nil {
+			expireTime = role.ExpiryDetail.ExpireTime
+		}
+	}
+
+	d.SetId(membership.Name)
+	d.Set("name", membership.Name)
+	d.Set("group", strings.Split(membership.Name, "/memberships/")[0])
+	if membership.PreferredMemberKey != nil {
+		d.Set("email", strings.ToLower(membership.PreferredMemberKey.Id))
+	}
+	d.Set("roles", roles)
+	d.Set("expire_time", expireTime)
+	d.Set("type", membership.Type)
+	d.Set("create_time", membership.CreateTime)
+	d.Set("update_time", membership.UpdateTime)
+
+	return nil
+}
+
+func resourceCloudIdentityGroupMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	var op *cloudidentity.Operation
+	var err error
+	err = retry(func() error {
+		op, err = config.cloudIdentity.Groups.Memberships.Delete(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err == nil {
+		err = cloudIdentityOperationError(op)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting group membership: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using the name (groups/{group_id}/memberships/{membership_id})
+// or [group name]/[member email]
+func resourceCloudIdentityGroupMembershipImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+
+	s := strings.Split(d.Id(), "/")
+	if len(s) == 3 && s[0] == "groups" {
+		group, member := strings.Join(s[:2], "/"), strings.ToLower(s[2])
+		lookup, err := config.cloudIdentity.Groups.Memberships.Lookup(group).MemberKeyId(member).Do()
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error fetching group membership, make sure the member exists: %s ", err)
+		}
+		d.SetId(lookup.Name)
+	} else if len(s) != 4 || s[0] != "groups" || s[2] != "memberships" {
+		return nil, fmt.Errorf("[WARN] Import via groups/[group id]/memberships/[membership id] or groups/[group id]/[member email]")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,82 @@
+package gsuite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"google.golang.org/api/cloudidentity/v1"
+)
+
+func TestCloudIdentityDynamicGroupMetadata(t *testing.T) {
+	raw := map[string]interface{}{
+		"email":  "engineering@domain.ext",
+		"labels": []interface{}{"discussion_forum"},
+		"dynamic_group_metadata": []interface{}{
+			map[string]interface{}{
+				"query": []interface{}{
+					map[string]interface{}{
+						"resource_type": "USER",
+						"query":         "user.organizations.exists(org, org.department=='engineering')",
+					},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceCloudIdentityGroup().Schema, raw)
+
+	metadata := expandCloudIdentityDynamicGroupMetadata(d)
+	if metadata == nil || len(metadata.Queries) != 1 || metadata.Queries[0].ResourceType != "USER" {
+		t.Fatalf("unexpected metadata: %#v", metadata)
+	}
+
+	labels := expandCloudIdentityGroupLabels(d)
+	expectedLabels := map[string]string{
+		"cloudidentity.googleapis.com/groups.discussion_forum": "",
+		"cloudidentity.googleapis.com/groups.dynamic":          "",
+	}
+	if !reflect.DeepEqual(labels, expectedLabels) {
+		t.Errorf("expected labels %v, got %v", expectedLabels, labels)
+	}
+
+	metadata.Status = &cloudidentity.DynamicGroupStatus{Status: "UP_TO_DATE"}
+	flattened := flattenCloudIdentityDynamicGroupMetadata(metadata)
+	if len(flattened) != 1 || flattened[0]["status"] != "UP_TO_DATE" {
+		t.Errorf("unexpected flattened metadata: %#v", flattened)
+	}
+}
+
+func TestResourceCloudIdentityGroupMembershipCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		roles      []interface{}
+		expireTime string
+		err        string
+	}{
+		{[]interface{}{"MEMBER"}, "", ""},
+		{[]interface{}{"MEMBER"}, "2030-01-01T00:00:00Z", ""},
+		{[]interface{}{"MEMBER", "OWNER"}, "", ""},
+		{[]interface{}{"OWNER"}, "", "roles must contain MEMBER"},
+		{[]interface{}{"MEMBER", "MANAGER"}, "2030-01-01T00:00:00Z", "expire_time can only be set"},
+	}
+
+	for _, c := range cases {
+		raw := map[string]interface{}{
+			"group": "groups/abc",
+			"email": "member@domain.ext",
+			"roles": c.roles,
+		}
+		if c.expireTime != "" {
+			raw["expire_time"] = c.expireTime
+		}
+
+		_, err := resourceCloudIdentityGroupMembership().Diff(nil, terraform.NewResourceConfigRaw(raw), nil)
+		if c.err == "" && err != nil {
+			t.Errorf("%v: unexpected error: %s", c.roles, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%v: expected error %q, got %v", c.roles, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/drive/v3"
+)
+
+// Permission on a shared drive or on a file
+func resourceDrivePermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDrivePermissionCreate,
+		Read:   resourceDrivePermissionRead,
+		Update: resourceDrivePermissionUpdate,
+		Delete: resourceDrivePermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDrivePermissionImporter,
+		},
+
+		CustomizeDiff: resourceDrivePermissionCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			// The id of a shared drive or of a file
+			"file_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"organizer", "fileOrganizer", "writer", "commenter", "reader"}, false),
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group", "domain"}, false),
+			},
+
+			// Email of the user or group, required for these types
+			"email_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			// Required for the domain type
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+			},
+
+			"send_notification_email": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"email_message": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"permission_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDrivePermissionCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("email_address") || !d.NewValueKnown("domain") {
+		return nil
+	}
+
+	return validateDrivePermissionTarget(d.Get("type").(string), d.Get("email_address").(string), d.Get("domain").(string))
+}
+
+func validateDrivePermissionTarget(permissionType, emailAddress, domain string) error {
+	if permissionType == "domain" {
+		if domain == "" {
+			return fmt.Errorf("domain is required when type is domain")
+		}
+		if emailAddress != "" {
+			return fmt.Errorf("email_address can't be set when type is domain")
+		}
+		return nil
+	}
+
+	if emailAddress == "" {
+		return fmt.Errorf("email_address is required when type is %s", permissionType)
+	}
+	if domain != "" {
+		return fmt.Errorf("domain can't be set when type is %s", permissionType)
+	}
+	return nil
+}
+
+func resourceDrivePermissionCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	fileID := d.Get("file_id").(string)
+	permission := &drive.Permission{
+		Role:         d.Get("role").(string),
+		Type:         d.Get("type").(string),
+		EmailAddress: strings.ToLower(d.Get("email_address").(string)),
+		Domain:       strings.ToLower(d.Get("domain").(string)),
+	}
+
+	call := driveSvc.Permissions.Create(fileID, permission).
+		SupportsAllDrives(true).
+		UseDomainAdminAccess(true).
+		SendNotificationEmail(d.Get("send_notification_email").(bool))
+	if message := d.Get("email_message").(string); message != "" {
+		call = call.EmailMessage(message)
+	}
+
+	var created *drive.Permission
+	err = retry(func() error {
+		created, err = call.Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating drive permission: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", fileID, created.Id))
+	log.Printf("[INFO] Created drive permission %s on %s", created.Id, fileID)
+
+	return resourceDrivePermissionRead(d, meta)
+}
+
+func resourceDrivePermissionUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if !d.HasChange("role") {
+		return resourceDrivePermissionRead(d, meta)
+	}
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	permission := &drive.Permission{
+		Role: d.Get("role").(string),
+	}
+
+	log.Printf("[DEBUG] Updating drive permission %s", d.Id())
+	err = retry(func() error {
+		_, err = driveSvc.Permissions.Update(d.Get("file_id").(string), d.Get("permission_id").(string), permission).
+			SupportsAllDrives(true).
+			UseDomainAdminAccess(true).
+			Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating drive permission: %s", err)
+	}
+
+	return resourceDrivePermissionRead(d, meta)
+}
+
+func resourceDrivePermissionRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	fileID, permissionID := drivePermissionSplitId(d.Id())
+
+	var permission *drive.Permission
+	err = retry(func() error {
+		permission, err = driveSvc.Permissions.Get(fileID, permissionID).
+			SupportsAllDrives(true).
+			UseDomainAdminAccess(true).
+			Fields("id", "role", "type", "emailAddress", "domain", "displayName").
+			Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Drive permission %q", d.Id()))
+	}
+
+	d.Set("file_id", fileID)
+	d.Set("permission_id", permission.Id)
+	d.Set("role", permission.Role)
+	d.Set("type", permission.Type)
+	d.Set("email_address", strings.ToLower(permission.EmailAddress))
+	d.Set("domain", strings.ToLower(permission.Domain))
+	d.Set("display_name", permission.DisplayName)
+
+	return nil
+}
+
+func resourceDrivePermissionDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return driveSvc.Permissions.Delete(d.Get("file_id").(string), d.Get("permission_id").(string)).
+			SupportsAllDrives(true).
+			UseDomainAdminAccess(true).
+			Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting drive permission: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// drivePermissionSplitId splits [file id]/[permission id], neither contains a slash.
+func drivePermissionSplitId(id string) (string, string) {
+	s := strings.SplitN(id, "/", 2)
+	if len(s) != 2 {
+		return id, ""
+	}
+	return s[0], s[1]
+}
+
+// Allow importing using [file id]/[permission id]
+func resourceDrivePermissionImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	fileID, permissionID := drivePermissionSplitId(d.Id())
+	if fileID == "" || permissionID == "" {
+		return nil, fmt.Errorf("[WARN] Import via [file id]/[permission id]")
+	}
+
+	d.Set("file_id", fileID)
+	d.Set("permission_id", permissionID)
+	d.Set("send_notification_email", false)
+
+	return []*schema.ResourceData{d}, nil
+}
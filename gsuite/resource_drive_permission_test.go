@@ -0,0 +1,43 @@
+package gsuite
+
+import (
+	"testing"
+)
+
+func TestDrivePermissionSplitId(t *testing.T) {
+	cases := map[string][2]string{
+		"0AExampleDriveId/12345678901234567890": {"0AExampleDriveId", "12345678901234567890"},
+		"1FileId/12345678901234567890k":         {"1FileId", "12345678901234567890k"},
+		"0AExampleDriveId":                      {"0AExampleDriveId", ""},
+	}
+	for id, expected := range cases {
+		fileID, permissionID := drivePermissionSplitId(id)
+		if fileID != expected[0] || permissionID != expected[1] {
+			t.Errorf("drivePermissionSplitId(%q): expected %v, got [%s %s]", id, expected, fileID, permissionID)
+		}
+	}
+}
+
+func TestValidateDrivePermissionTarget(t *testing.T) {
+	cases := []struct {
+		permissionType, emailAddress, domain string
+		valid                                bool
+	}{
+		{"user", "john@domain.ext", "", true},
+		{"group", "team@domain.ext", "", true},
+		{"domain", "", "domain.ext", true},
+		{"user", "", "", false},
+		{"group", "team@domain.ext", "domain.ext", false},
+		{"domain", "", "", false},
+		{"domain", "john@domain.ext", "domain.ext", false},
+	}
+	for _, c := range cases {
+		err := validateDrivePermissionTarget(c.permissionType, c.emailAddress, c.domain)
+		if c.valid && err != nil {
+			t.Errorf("validateDrivePermissionTarget(%q, %q, %q): unexpected error %s", c.permissionType, c.emailAddress, c.domain, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("validateDrivePermissionTarget(%q, %q, %q): expected an error", c.permissionType, c.emailAddress, c.domain)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+// What happens to a message once it is forwarded or fetched
+var gmailDispositions = []string{"leaveInInbox", "archive", "trash", "markRead"}
+
+func resourceGmailAutoForwarding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailAutoForwardingUpdate,
+		Read:   resourceGmailAutoForwardingRead,
+		Update: resourceGmailAutoForwardingUpdate,
+		Delete: resourceGmailAutoForwardingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailUserImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			// Must be a verified forwarding address of the user
+			"email_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+			},
+
+			"disposition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "leaveInInbox",
+				ValidateFunc: validation.StringInSlice(gmailDispositions, false),
+			},
+		}),
+	}
+}
+
+func gmailAutoForwardingUpdate(config *Config, user string, autoForwarding *gmail.AutoForwarding) error {
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	return retry(func() error {
+		_, err = gmailSvc.Users.Settings.UpdateAutoForwarding(user, autoForwarding).Do()
+		return err
+	}, config.TimeoutMinutes)
+}
+
+func resourceGmailAutoForwardingUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	autoForwarding := &gmail.AutoForwarding{
+		Enabled:         d.Get("enabled").(bool),
+		Disposition:     d.Get("disposition").(string),
+		ForceSendFields: []string{"Enabled"},
+	}
+	if autoForwarding.Enabled {
+		autoForwarding.EmailAddress = strings.ToLower(d.Get("email_address").(string))
+	}
+
+	log.Printf("[DEBUG] Updating auto-forwarding of %s", user)
+	if err := gmailAutoForwardingUpdate(config, user, autoForwarding); err != nil {
+		return fmt.Errorf("[ERROR] Error updating auto-forwarding: %s", err)
+	}
+
+	d.SetId(user)
+	return resourceGmailAutoForwardingRead(d, meta)
+}
+
+func resourceGmailAutoForwardingRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var autoForwarding *gmail.AutoForwarding
+	err = retry(func() error {
+		autoForwarding, err = gmailSvc.Users.Settings.GetAutoForwarding(user).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Auto-forwarding of %q", user))
+	}
+
+	d.Set("enabled", autoForwarding.Enabled)
+	d.Set("email_address", strings.ToLower(autoForwarding.EmailAddress))
+	if autoForwarding.Disposition != "" && autoForwarding.Disposition != "dispositionUnspecified" {
+		d.Set("disposition", autoForwarding.Disposition)
+	}
+
+	return nil
+}
+
+// Auto-forwarding is disabled when the resource is destroyed
+func resourceGmailAutoForwardingDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	autoForwarding := &gmail.AutoForwarding{
+		Enabled:         false,
+		ForceSendFields: []string{"Enabled"},
+	}
+	if err := gmailAutoForwardingUpdate(config, user, autoForwarding); err != nil {
+		return fmt.Errorf("[ERROR] Error disabling auto-forwarding: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
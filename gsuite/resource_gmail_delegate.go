@@ -0,0 +1,194 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceGmailDelegate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailDelegateCreate,
+		Read:   resourceGmailDelegateRead,
+		Delete: resourceGmailDelegateDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailDelegateImporter,
+		},
+
+		// user is the delegator, whose mailbox is shared
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"delegate_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			// Whether to wait until the delegate is accepted
+			"wait_for_acceptance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+// gmailDelegateError explains the errors of the API when the delegate can't
+// be added, most often because it is not a user of the same organization.
+func gmailDelegateError(err error, user, delegate string) error {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || (gerr.Code != 400 && gerr.Code != 403 && gerr.Code != 404) {
+		return err
+	}
+
+	userDomain := user[strings.LastIndex(user, "@")+1:]
+	delegateDomain := delegate[strings.LastIndex(delegate, "@")+1:]
+	if !strings.EqualFold(userDomain, delegateDomain) {
+		return fmt.Errorf("%s: the delegate %s is not in the domain %s of %s; delegates must be users of the same organization, "+
+			"in its primary or secondary domains, with Gmail enabled", err, delegate, userDomain, user)
+	}
+	if gerr.Code == 404 {
+		return fmt.Errorf("%s: the delegate %s must be an existing user with Gmail enabled", err, delegate)
+	}
+	return fmt.Errorf("%s: make sure mail delegation is allowed for the organizational unit of %s, and that %s is an active user", err, user, delegate)
+}
+
+func resourceGmailDelegateCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	delegate := &gmail.Delegate{
+		DelegateEmail: strings.ToLower(d.Get("delegate_email").(string)),
+	}
+
+	var created *gmail.Delegate
+	err = retry(func() error {
+		created, err = gmailSvc.Users.Settings.Delegates.Create(user, delegate).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating delegate: %s", gmailDelegateError(err, user, delegate.DelegateEmail))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", user, delegate.DelegateEmail))
+	log.Printf("[INFO] Created delegate %s for %s (%s)", delegate.DelegateEmail, user, created.VerificationStatus)
+
+	if d.Get("wait_for_acceptance").(bool) {
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"accepted"},
+			Refresh: func() (interface{}, string, error) {
+				var current *gmail.Delegate
+				var err error
+				err = retryNotFound(func() error {
+					current, err = gmailSvc.Users.Settings.Delegates.Get(user, delegate.DelegateEmail).Do()
+					return err
+				}, config.TimeoutMinutes)
+				if err != nil {
+					return nil, "", err
+				}
+				return current, current.VerificationStatus, nil
+			},
+			Timeout:    time.Duration(config.TimeoutMinutes) * time.Minute,
+			Delay:      2 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+
+		if _, err = stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for delegate %s to be accepted: %s", delegate.DelegateEmail, err)
+		}
+	}
+
+	return resourceGmailDelegateRead(d, meta)
+}
+
+func resourceGmailDelegateRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var delegate *gmail.Delegate
+	err = retry(func() error {
+		delegate, err = gmailSvc.Users.Settings.Delegates.Get(user, d.Get("delegate_email").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Delegate %q", d.Id()))
+	}
+
+	// A delegate which is not accepted anymore has to be added again
+	if delegate.VerificationStatus == "rejected" || delegate.VerificationStatus == "expired" {
+		log.Printf("[WARN] Delegate %s is %s, removing it from the state", d.Id(), delegate.VerificationStatus)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("delegate_email", strings.ToLower(delegate.DelegateEmail))
+	d.Set("verification_status", delegate.VerificationStatus)
+
+	return nil
+}
+
+func resourceGmailDelegateDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return gmailSvc.Users.Settings.Delegates.Delete(user, d.Get("delegate_email").(string)).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting delegate: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [delegator email]/[delegate email]
+func resourceGmailDelegateImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [delegator email]/[delegate email]")
+	}
+
+	user, delegate := strings.ToLower(s[0]), strings.ToLower(s[1])
+	d.SetId(fmt.Sprintf("%s/%s", user, delegate))
+	d.Set("user", user)
+	d.Set("delegate_email", delegate)
+	d.Set("wait_for_acceptance", true)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,30 @@
+package gsuite
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestGmailDelegateError(t *testing.T) {
+	cases := []struct {
+		err      error
+		delegate string
+		contains string
+	}{
+		{errors.New("connection reset"), "assistant@domain.ext", "connection reset"},
+		{&googleapi.Error{Code: 500}, "assistant@domain.ext", "500"},
+		{&googleapi.Error{Code: 400}, "assistant@other.ext", "not in the domain domain.ext"},
+		{&googleapi.Error{Code: 404}, "assistant@domain.ext", "must be an existing user"},
+		{&googleapi.Error{Code: 403}, "assistant@domain.ext", "mail delegation is allowed"},
+	}
+
+	for _, c := range cases {
+		err := gmailDelegateError(c.err, "ceo@domain.ext", c.delegate)
+		if !strings.Contains(err.Error(), c.contains) {
+			t.Errorf("expected %q to contain %q", err.Error(), c.contains)
+		}
+	}
+}
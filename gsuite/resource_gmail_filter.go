@@ -0,0 +1,268 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+// Filters can't be updated with the API, every change replaces the filter
+func resourceGmailFilter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailFilterCreate,
+		Read:   resourceGmailFilterRead,
+		Delete: resourceGmailFilterDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailFilterImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"criteria": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"to": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"subject": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						// Same format as the Gmail search box
+						"query": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"negated_query": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"has_attachment": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						"exclude_chats": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+						// In bytes
+						"size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+						"size_comparison": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice([]string{"smaller", "larger"}, false),
+						},
+					},
+				},
+			},
+
+			"action": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Ids of user labels (label_id of gsuite_gmail_label) or
+						// system labels, e.g. INBOX, UNREAD, STARRED
+						"add_label_ids": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"remove_label_ids": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						// Must be a verified forwarding address of the user
+						"forward": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"filter_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func expandGmailFilter(d *schema.ResourceData) *gmail.Filter {
+	filter := &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{},
+		Action:   &gmail.FilterAction{},
+	}
+
+	if criteria := d.Get("criteria").([]interface{}); len(criteria) > 0 && criteria[0] != nil {
+		c := criteria[0].(map[string]interface{})
+		filter.Criteria = &gmail.FilterCriteria{
+			From:           c["from"].(string),
+			To:             c["to"].(string),
+			Subject:        c["subject"].(string),
+			Query:          c["query"].(string),
+			NegatedQuery:   c["negated_query"].(string),
+			HasAttachment:  c["has_attachment"].(bool),
+			ExcludeChats:   c["exclude_chats"].(bool),
+			Size:           int64(c["size"].(int)),
+			SizeComparison: c["size_comparison"].(string),
+		}
+	}
+
+	if action := d.Get("action").([]interface{}); len(action) > 0 && action[0] != nil {
+		a := action[0].(map[string]interface{})
+		filter.Action = &gmail.FilterAction{
+			AddLabelIds:    convertStringSet(a["add_label_ids"].(*schema.Set)),
+			RemoveLabelIds: convertStringSet(a["remove_label_ids"].(*schema.Set)),
+			Forward:        a["forward"].(string),
+		}
+	}
+
+	return filter
+}
+
+func flattenGmailFilter(filter *gmail.Filter) ([]map[string]interface{}, []map[string]interface{}) {
+	criteria := map[string]interface{}{}
+	if c := filter.Criteria; c != nil {
+		criteria["from"] = c.From
+		criteria["to"] = c.To
+		criteria["subject"] = c.Subject
+		criteria["query"] = c.Query
+		criteria["negated_query"] = c.NegatedQuery
+		criteria["has_attachment"] = c.HasAttachment
+		criteria["exclude_chats"] = c.ExcludeChats
+		criteria["size"] = int(c.Size)
+		if c.SizeComparison != "unspecified" {
+			criteria["size_comparison"] = c.SizeComparison
+		}
+	}
+
+	action := map[string]interface{}{}
+	if a := filter.Action; a != nil {
+		action["add_label_ids"] = a.AddLabelIds
+		action["remove_label_ids"] = a.RemoveLabelIds
+		action["forward"] = a.Forward
+	}
+
+	return []map[string]interface{}{criteria}, []map[string]interface{}{action}
+}
+
+func resourceGmailFilterCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var created *gmail.Filter
+	err = retry(func() error {
+		created, err = gmailSvc.Users.Settings.Filters.Create(user, expandGmailFilter(d)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating filter: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", user, created.Id))
+	d.Set("filter_id", created.Id)
+	log.Printf("[INFO] Created filter %s for %s", created.Id, user)
+
+	return resourceGmailFilterRead(d, meta)
+}
+
+func resourceGmailFilterRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var filter *gmail.Filter
+	err = retry(func() error {
+		filter, err = gmailSvc.Users.Settings.Filters.Get(user, d.Get("filter_id").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Filter %q", d.Id()))
+	}
+
+	criteria, action := flattenGmailFilter(filter)
+	if err := d.Set("criteria", criteria); err != nil {
+		return fmt.Errorf("Error setting criteria in state: %s", err.Error())
+	}
+	if err := d.Set("action", action); err != nil {
+		return fmt.Errorf("Error setting action in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+func resourceGmailFilterDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return gmailSvc.Users.Settings.Filters.Delete(user, d.Get("filter_id").(string)).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting filter: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [user]/[filter id]
+func resourceGmailFilterImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [user email]/[filter id]")
+	}
+
+	user := strings.ToLower(s[0])
+	d.SetId(fmt.Sprintf("%s/%s", user, s[1]))
+	d.Set("user", user)
+	d.Set("filter_id", s[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,51 @@
+package gsuite
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestExpandFlattenGmailFilter(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceGmailFilter().Schema, map[string]interface{}{
+		"user": "support@domain.ext",
+		"criteria": []interface{}{
+			map[string]interface{}{
+				"from":            "alerts@vendor.ext",
+				"has_attachment":  true,
+				"size":            1048576,
+				"size_comparison": "larger",
+			},
+		},
+		"action": []interface{}{
+			map[string]interface{}{
+				"add_label_ids":    []interface{}{"Label_1", "STARRED"},
+				"remove_label_ids": []interface{}{"INBOX"},
+			},
+		},
+	})
+
+	filter := expandGmailFilter(d)
+	if filter.Criteria.From != "alerts@vendor.ext" || !filter.Criteria.HasAttachment || filter.Criteria.Size != 1048576 || filter.Criteria.SizeComparison != "larger" {
+		t.Errorf("unexpected criteria: %+v", filter.Criteria)
+	}
+	sort.Strings(filter.Action.AddLabelIds)
+	if !reflect.DeepEqual(filter.Action.AddLabelIds, []string{"Label_1", "STARRED"}) || !reflect.DeepEqual(filter.Action.RemoveLabelIds, []string{"INBOX"}) {
+		t.Errorf("unexpected action: %+v", filter.Action)
+	}
+
+	criteria, action := flattenGmailFilter(filter)
+	if err := d.Set("criteria", criteria); err != nil {
+		t.Fatalf("unexpected error setting criteria: %s", err)
+	}
+	if err := d.Set("action", action); err != nil {
+		t.Fatalf("unexpected error setting action: %s", err)
+	}
+	flattened := expandGmailFilter(d)
+	sort.Strings(flattened.Action.AddLabelIds)
+	if !reflect.DeepEqual(flattened, filter) {
+		t.Errorf("expected the filter to be unchanged after flattening, got %+v", flattened)
+	}
+}
@@ -0,0 +1,129 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/gmail/v1"
+)
+
+func resourceGmailForwardingAddress() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailForwardingAddressCreate,
+		Read:   resourceGmailForwardingAddressRead,
+		Delete: resourceGmailForwardingAddressDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailForwardingAddressImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"forwarding_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			// accepted, or pending until the owner of an external address confirms it
+			"verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func resourceGmailForwardingAddressCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	forwardingAddress := &gmail.ForwardingAddress{
+		ForwardingEmail: strings.ToLower(d.Get("forwarding_email").(string)),
+	}
+
+	var created *gmail.ForwardingAddress
+	err = retry(func() error {
+		created, err = gmailSvc.Users.Settings.ForwardingAddresses.Create(user, forwardingAddress).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating forwarding address: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", user, strings.ToLower(created.ForwardingEmail)))
+	log.Printf("[INFO] Created forwarding address %s for %s (%s)", created.ForwardingEmail, user, created.VerificationStatus)
+
+	return resourceGmailForwardingAddressRead(d, meta)
+}
+
+func resourceGmailForwardingAddressRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var forwardingAddress *gmail.ForwardingAddress
+	err = retry(func() error {
+		forwardingAddress, err = gmailSvc.Users.Settings.ForwardingAddresses.Get(user, d.Get("forwarding_email").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Forwarding address %q", d.Id()))
+	}
+
+	d.Set("forwarding_email", strings.ToLower(forwardingAddress.ForwardingEmail))
+	d.Set("verification_status", forwardingAddress.VerificationStatus)
+
+	return nil
+}
+
+func resourceGmailForwardingAddressDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return gmailSvc.Users.Settings.ForwardingAddresses.Delete(user, d.Get("forwarding_email").(string)).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting forwarding address: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [user]/[forwarding email]
+func resourceGmailForwardingAddressImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [user email]/[forwarding email]")
+	}
+
+	user, forwardingEmail := strings.ToLower(s[0]), strings.ToLower(s[1])
+	d.SetId(fmt.Sprintf("%s/%s", user, forwardingEmail))
+	d.Set("user", user)
+	d.Set("forwarding_email", forwardingEmail)
+
+	return []*schema.ResourceData{d}, nil
+}
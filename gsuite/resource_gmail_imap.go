@@ -0,0 +1,117 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+func resourceGmailImap() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailImapUpdate,
+		Read:   resourceGmailImapRead,
+		Update: resourceGmailImapUpdate,
+		Delete: resourceGmailImapDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailUserImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			// Expunge messages immediately when they are marked as deleted
+			"auto_expunge": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"expunge_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "archive",
+				ValidateFunc: validation.StringInSlice([]string{"archive", "trash", "deleteForever"}, false),
+			},
+
+			// Maximum number of messages per folder, 0 means no limit
+			"max_folder_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntInSlice([]int{0, 1000, 2000, 5000, 10000}),
+			},
+		}),
+	}
+}
+
+func resourceGmailImapUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	imap := &gmail.ImapSettings{
+		Enabled:         d.Get("enabled").(bool),
+		AutoExpunge:     d.Get("auto_expunge").(bool),
+		ExpungeBehavior: d.Get("expunge_behavior").(string),
+		MaxFolderSize:   int64(d.Get("max_folder_size").(int)),
+		ForceSendFields: []string{"Enabled", "AutoExpunge", "MaxFolderSize"},
+	}
+
+	log.Printf("[DEBUG] Updating IMAP settings of %s", user)
+	err = retry(func() error {
+		_, err = gmailSvc.Users.Settings.UpdateImap(user, imap).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating IMAP settings: %s", err)
+	}
+
+	d.SetId(user)
+	return resourceGmailImapRead(d, meta)
+}
+
+func resourceGmailImapRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var imap *gmail.ImapSettings
+	err = retry(func() error {
+		imap, err = gmailSvc.Users.Settings.GetImap(user).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("IMAP settings of %q", user))
+	}
+
+	d.Set("enabled", imap.Enabled)
+	d.Set("auto_expunge", imap.AutoExpunge)
+	if imap.ExpungeBehavior != "" && imap.ExpungeBehavior != "expungeBehaviorUnspecified" {
+		d.Set("expunge_behavior", imap.ExpungeBehavior)
+	}
+	d.Set("max_folder_size", int(imap.MaxFolderSize))
+
+	return nil
+}
+
+// The IMAP settings are left as they are when the resource is destroyed
+func resourceGmailImapDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] Removing the IMAP settings of %s from the state only", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,212 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+func resourceGmailLabel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailLabelCreate,
+		Read:   resourceGmailLabelRead,
+		Update: resourceGmailLabelUpdate,
+		Delete: resourceGmailLabelDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailLabelImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			// Nested labels use a slash, e.g. Support/Escalated
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"label_list_visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "labelShow",
+				ValidateFunc: validation.StringInSlice([]string{"labelShow", "labelShowIfUnread", "labelHide"}, false),
+			},
+
+			"message_list_visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "show",
+				ValidateFunc: validation.StringInSlice([]string{"show", "hide"}, false),
+			},
+
+			// Only colors of the Gmail palette are accepted, e.g. #4a86e8
+			"color": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"background_color": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"text_color": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"label_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func expandGmailLabel(d *schema.ResourceData) *gmail.Label {
+	label := &gmail.Label{
+		Name:                  d.Get("name").(string),
+		LabelListVisibility:   d.Get("label_list_visibility").(string),
+		MessageListVisibility: d.Get("message_list_visibility").(string),
+	}
+
+	if color := d.Get("color").([]interface{}); len(color) > 0 && color[0] != nil {
+		c := color[0].(map[string]interface{})
+		label.Color = &gmail.LabelColor{
+			BackgroundColor: strings.ToLower(c["background_color"].(string)),
+			TextColor:       strings.ToLower(c["text_color"].(string)),
+		}
+	} else {
+		label.NullFields = append(label.NullFields, "Color")
+	}
+
+	return label
+}
+
+func resourceGmailLabelCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailLabelsScopes)
+	if err != nil {
+		return err
+	}
+
+	label := expandGmailLabel(d)
+	label.NullFields = nil
+
+	var created *gmail.Label
+	err = retry(func() error {
+		created, err = gmailSvc.Users.Labels.Create(user, label).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating label: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", user, created.Id))
+	d.Set("label_id", created.Id)
+	log.Printf("[INFO] Created label %s for %s", created.Name, user)
+
+	return resourceGmailLabelRead(d, meta)
+}
+
+func resourceGmailLabelUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailLabelsScopes)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating label %s of %s", d.Get("name").(string), user)
+	err = retry(func() error {
+		_, err = gmailSvc.Users.Labels.Patch(user, d.Get("label_id").(string), expandGmailLabel(d)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating label: %s", err)
+	}
+
+	return resourceGmailLabelRead(d, meta)
+}
+
+func resourceGmailLabelRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailLabelsScopes)
+	if err != nil {
+		return err
+	}
+
+	var label *gmail.Label
+	err = retry(func() error {
+		label, err = gmailSvc.Users.Labels.Get(user, d.Get("label_id").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Label %q", d.Id()))
+	}
+
+	d.Set("name", label.Name)
+	if label.LabelListVisibility != "" {
+		d.Set("label_list_visibility", label.LabelListVisibility)
+	}
+	if label.MessageListVisibility != "" {
+		d.Set("message_list_visibility", label.MessageListVisibility)
+	}
+	if label.Color == nil {
+		d.Set("color", nil)
+	} else {
+		d.Set("color", []map[string]interface{}{
+			{
+				"background_color": label.Color.BackgroundColor,
+				"text_color":       label.Color.TextColor,
+			},
+		})
+	}
+
+	return nil
+}
+
+func resourceGmailLabelDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailLabelsScopes)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return gmailSvc.Users.Labels.Delete(user, d.Get("label_id").(string)).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting label: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [user]/[label id]
+func resourceGmailLabelImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [user email]/[label id]")
+	}
+
+	user := strings.ToLower(s[0])
+	d.SetId(fmt.Sprintf("%s/%s", user, s[1]))
+	d.Set("user", user)
+	d.Set("label_id", s[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,99 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+func resourceGmailPop() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailPopUpdate,
+		Read:   resourceGmailPopRead,
+		Update: resourceGmailPopUpdate,
+		Delete: resourceGmailPopDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailUserImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			// Which messages can be fetched with POP, disabled turns POP off
+			"access_window": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"disabled", "fromNowOn", "allMail"}, false),
+			},
+
+			"disposition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "leaveInInbox",
+				ValidateFunc: validation.StringInSlice(gmailDispositions, false),
+			},
+		}),
+	}
+}
+
+func resourceGmailPopUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	pop := &gmail.PopSettings{
+		AccessWindow: d.Get("access_window").(string),
+		Disposition:  d.Get("disposition").(string),
+	}
+
+	log.Printf("[DEBUG] Updating POP settings of %s", user)
+	err = retry(func() error {
+		_, err = gmailSvc.Users.Settings.UpdatePop(user, pop).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating POP settings: %s", err)
+	}
+
+	d.SetId(user)
+	return resourceGmailPopRead(d, meta)
+}
+
+func resourceGmailPopRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var pop *gmail.PopSettings
+	err = retry(func() error {
+		pop, err = gmailSvc.Users.Settings.GetPop(user).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("POP settings of %q", user))
+	}
+
+	d.Set("access_window", pop.AccessWindow)
+	if pop.Disposition != "" && pop.Disposition != "dispositionUnspecified" {
+		d.Set("disposition", pop.Disposition)
+	}
+
+	return nil
+}
+
+// The POP settings are left as they are when the resource is destroyed
+func resourceGmailPopDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[WARN] Removing the POP settings of %s from the state only", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,332 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+)
+
+var schemaGmailUser = map[string]*schema.Schema{
+	// The user whose settings are managed, the API is called as this user
+	"user": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		StateFunc: func(val interface{}) string {
+			return strings.ToLower(val.(string))
+		},
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return strings.ToLower(old) == strings.ToLower(new)
+		},
+		ValidateFunc: validateEmail,
+	},
+}
+
+// Allow importing settings of a user using the user email
+func resourceGmailUserImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	user := strings.ToLower(d.Id())
+	d.SetId(user)
+	d.Set("user", user)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGmailSendAs() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailSendAsCreate,
+		Read:   resourceGmailSendAsRead,
+		Update: resourceGmailSendAsUpdate,
+		Delete: resourceGmailSendAsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailSendAsImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"send_as_email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"reply_to_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// HTML
+			"signature": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Only one address is the default, it can only be set to true
+			"is_default": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"treat_as_alias": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// SMTP relay to send the mails through, only for external addresses
+			"smtp_msa": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 65535),
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						// Write-only, it is never returned by the API
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"security_mode": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "starttls",
+							ValidateFunc: validation.StringInSlice([]string{"none", "ssl", "starttls"}, false),
+						},
+					},
+				},
+			},
+
+			"is_primary": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func expandGmailSendAs(d *schema.ResourceData) *gmail.SendAs {
+	sendAs := &gmail.SendAs{
+		SendAsEmail:     strings.ToLower(d.Get("send_as_email").(string)),
+		DisplayName:     d.Get("display_name").(string),
+		ReplyToAddress:  d.Get("reply_to_address").(string),
+		Signature:       d.Get("signature").(string),
+		IsDefault:       d.Get("is_default").(bool),
+		TreatAsAlias:    d.Get("treat_as_alias").(bool),
+		ForceSendFields: []string{"DisplayName", "ReplyToAddress", "Signature", "TreatAsAlias"},
+	}
+
+	if smtpMsa := d.Get("smtp_msa").([]interface{}); len(smtpMsa) > 0 && smtpMsa[0] != nil {
+		msa := smtpMsa[0].(map[string]interface{})
+		sendAs.SmtpMsa = &gmail.SmtpMsa{
+			Host:         msa["host"].(string),
+			Port:         int64(msa["port"].(int)),
+			Username:     msa["username"].(string),
+			Password:     msa["password"].(string),
+			SecurityMode: msa["security_mode"].(string),
+		}
+	}
+
+	return sendAs
+}
+
+// gmailSendAsCreateOrPatch creates a send-as address, or updates it when it
+// already exists: the primary address and existing aliases always exist.
+// Conflicts are not retried, so they fall back to the update right away.
+func gmailSendAsCreateOrPatch(user, email string, create, patch func() (*gmail.SendAs, error), minutes int) (*gmail.SendAs, error) {
+	var sendAs *gmail.SendAs
+	var err error
+	err = retryPassDuplicate(func() error {
+		sendAs, err = create()
+		return err
+	}, minutes)
+
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 409 {
+		log.Printf("[INFO] Send-as address %s already exists for %s, updating it", email, user)
+		err = retry(func() error {
+			sendAs, err = patch()
+			return err
+		}, minutes)
+	}
+
+	return sendAs, err
+}
+
+func resourceGmailSendAsCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	sendAs := expandGmailSendAs(d)
+
+	created, err := gmailSendAsCreateOrPatch(user, sendAs.SendAsEmail,
+		func() (*gmail.SendAs, error) {
+			return gmailSvc.Users.Settings.SendAs.Create(user, sendAs).Do()
+		},
+		func() (*gmail.SendAs, error) {
+			return gmailSvc.Users.Settings.SendAs.Patch(user, sendAs.SendAsEmail, sendAs).Do()
+		}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating send-as address: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", user, created.SendAsEmail))
+	log.Printf("[INFO] Created send-as address %s for %s", created.SendAsEmail, user)
+
+	return resourceGmailSendAsRead(d, meta)
+}
+
+func resourceGmailSendAsUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	sendAs := expandGmailSendAs(d)
+	if !d.HasChange("smtp_msa") {
+		sendAs.SmtpMsa = nil
+	} else if sendAs.SmtpMsa == nil {
+		sendAs.NullFields = append(sendAs.NullFields, "SmtpMsa")
+	}
+
+	log.Printf("[DEBUG] Updating send-as address %s of %s", sendAs.SendAsEmail, user)
+	err = retry(func() error {
+		_, err = gmailSvc.Users.Settings.SendAs.Patch(user, sendAs.SendAsEmail, sendAs).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating send-as address: %s", err)
+	}
+
+	return resourceGmailSendAsRead(d, meta)
+}
+
+func resourceGmailSendAsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var sendAs *gmail.SendAs
+	err = retry(func() error {
+		sendAs, err = gmailSvc.Users.Settings.SendAs.Get(user, d.Get("send_as_email").(string)).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Send-as address %q", d.Id()))
+	}
+
+	d.Set("send_as_email", strings.ToLower(sendAs.SendAsEmail))
+	d.Set("display_name", sendAs.DisplayName)
+	d.Set("reply_to_address", sendAs.ReplyToAddress)
+	d.Set("signature", sendAs.Signature)
+	d.Set("is_default", sendAs.IsDefault)
+	d.Set("treat_as_alias", sendAs.TreatAsAlias)
+	d.Set("is_primary", sendAs.IsPrimary)
+	d.Set("verification_status", sendAs.VerificationStatus)
+
+	if sendAs.SmtpMsa == nil {
+		d.Set("smtp_msa", nil)
+	} else {
+		// The password is never returned, keep it from the configuration
+		password := ""
+		if v, ok := d.GetOk("smtp_msa.0.password"); ok {
+			password = v.(string)
+		}
+		d.Set("smtp_msa", []map[string]interface{}{
+			{
+				"host":          sendAs.SmtpMsa.Host,
+				"port":          int(sendAs.SmtpMsa.Port),
+				"username":      sendAs.SmtpMsa.Username,
+				"password":      password,
+				"security_mode": sendAs.SmtpMsa.SecurityMode,
+			},
+		})
+	}
+
+	return nil
+}
+
+func resourceGmailSendAsDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.Get("is_primary").(bool) {
+		log.Printf("[WARN] The primary address %s can't be deleted, removing it from the state only", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return gmailSvc.Users.Settings.SendAs.Delete(user, d.Get("send_as_email").(string)).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting send-as address: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Allow importing using [user]/[send-as email]
+func resourceGmailSendAsImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [user email]/[send-as email]")
+	}
+
+	user, sendAsEmail := strings.ToLower(s[0]), strings.ToLower(s[1])
+	d.SetId(fmt.Sprintf("%s/%s", user, sendAsEmail))
+	d.Set("user", user)
+	d.Set("send_as_email", sendAsEmail)
+
+	return []*schema.ResourceData{d}, nil
+}
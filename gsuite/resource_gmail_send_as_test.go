@@ -0,0 +1,44 @@
+package gsuite
+
+import (
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestGmailSendAsCreateOrPatch(t *testing.T) {
+	creates, patches := 0, 0
+	create := func() (*gmail.SendAs, error) {
+		creates++
+		return nil, &googleapi.Error{Code: 409, Message: "Duplicate send-as address"}
+	}
+	patch := func() (*gmail.SendAs, error) {
+		patches++
+		return &gmail.SendAs{SendAsEmail: "john@domain.ext", IsPrimary: true}, nil
+	}
+
+	sendAs, err := gmailSendAsCreateOrPatch("john@domain.ext", "john@domain.ext", create, patch, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if creates != 1 {
+		t.Errorf("expected the conflict not to be retried, created %d times", creates)
+	}
+	if patches != 1 || sendAs == nil || !sendAs.IsPrimary {
+		t.Errorf("expected the existing address to be updated, patched %d times: %v", patches, sendAs)
+	}
+
+	creates, patches = 0, 0
+	create = func() (*gmail.SendAs, error) {
+		creates++
+		return &gmail.SendAs{SendAsEmail: "alias@domain.ext"}, nil
+	}
+	sendAs, err = gmailSendAsCreateOrPatch("john@domain.ext", "alias@domain.ext", create, patch, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if creates != 1 || patches != 0 || sendAs.SendAsEmail != "alias@domain.ext" {
+		t.Errorf("expected the address to be created only, created %d and patched %d times", creates, patches)
+	}
+}
@@ -0,0 +1,122 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/gmail/v1"
+)
+
+// Shortcut for the signature of the primary address of a user
+func resourceGmailSignature() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailSignatureUpdate,
+		Read:   resourceGmailSignatureRead,
+		Update: resourceGmailSignatureUpdate,
+		Delete: resourceGmailSignatureDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailUserImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			// HTML
+			"signature": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"send_as_email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+// gmailPrimarySendAs returns the send-as settings of the primary address of a user.
+func gmailPrimarySendAs(config *Config, gmailSvc *gmail.Service, user string) (*gmail.SendAs, error) {
+	var response *gmail.ListSendAsResponse
+	var err error
+	err = retry(func() error {
+		response, err = gmailSvc.Users.Settings.SendAs.List(user).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sendAs := range response.SendAs {
+		if sendAs.IsPrimary {
+			return sendAs, nil
+		}
+	}
+	return nil, fmt.Errorf("no primary address found for %s", user)
+}
+
+func gmailSignaturePatch(config *Config, user, signature string) error {
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	primary, err := gmailPrimarySendAs(config, gmailSvc, user)
+	if err != nil {
+		return err
+	}
+
+	sendAs := &gmail.SendAs{
+		Signature:       signature,
+		ForceSendFields: []string{"Signature"},
+	}
+	return retry(func() error {
+		_, err = gmailSvc.Users.Settings.SendAs.Patch(user, primary.SendAsEmail, sendAs).Do()
+		return err
+	}, config.TimeoutMinutes)
+}
+
+func resourceGmailSignatureUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	log.Printf("[DEBUG] Updating signature of %s", user)
+	if err := gmailSignaturePatch(config, user, d.Get("signature").(string)); err != nil {
+		return fmt.Errorf("[ERROR] Error updating signature: %s", err)
+	}
+
+	d.SetId(user)
+	return resourceGmailSignatureRead(d, meta)
+}
+
+func resourceGmailSignatureRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	primary, err := gmailPrimarySendAs(config, gmailSvc, user)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Signature of %q", user))
+	}
+
+	d.Set("send_as_email", strings.ToLower(primary.SendAsEmail))
+	d.Set("signature", primary.Signature)
+
+	return nil
+}
+
+func resourceGmailSignatureDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	if err := gmailSignaturePatch(config, user, ""); err != nil {
+		return fmt.Errorf("[ERROR] Error removing signature: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
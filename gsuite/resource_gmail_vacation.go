@@ -0,0 +1,205 @@
+package gsuite
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"google.golang.org/api/gmail/v1"
+)
+
+func resourceGmailVacation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGmailVacationUpdate,
+		Read:   resourceGmailVacationRead,
+		Update: resourceGmailVacationUpdate,
+		Delete: resourceGmailVacationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGmailUserImporter,
+		},
+
+		Schema: mergeSchemas(schemaGmailUser, map[string]*schema.Schema{
+			"enable_auto_reply": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			"response_subject": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"response_body_plain_text": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"response_body_html": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"restrict_to_contacts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"restrict_to_domain": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// RFC3339, the API works with milliseconds since the epoch
+			"start_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+			},
+
+			"end_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+			},
+		}),
+	}
+}
+
+// suppressEquivalentRFC3339Time ignores differences in the time zone of a timestamp.
+func suppressEquivalentRFC3339Time(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+// gmailTimeMillis converts an RFC3339 timestamp (or "") to milliseconds since the epoch (or 0).
+func gmailTimeMillis(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+// gmailTimeRFC3339 converts milliseconds since the epoch (or 0) to an RFC3339 timestamp (or "").
+func gmailTimeRFC3339(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+func resourceGmailVacationUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	vacation := &gmail.VacationSettings{
+		EnableAutoReply:       d.Get("enable_auto_reply").(bool),
+		ResponseSubject:       d.Get("response_subject").(string),
+		ResponseBodyPlainText: d.Get("response_body_plain_text").(string),
+		ResponseBodyHtml:      d.Get("response_body_html").(string),
+		RestrictToContacts:    d.Get("restrict_to_contacts").(bool),
+		RestrictToDomain:      d.Get("restrict_to_domain").(bool),
+		ForceSendFields: []string{"EnableAutoReply", "ResponseSubject", "ResponseBodyPlainText",
+			"ResponseBodyHtml", "RestrictToContacts", "RestrictToDomain"},
+	}
+	if vacation.StartTime, err = gmailTimeMillis(d.Get("start_time").(string)); err != nil {
+		return err
+	}
+	if vacation.EndTime, err = gmailTimeMillis(d.Get("end_time").(string)); err != nil {
+		return err
+	}
+	if vacation.StartTime == 0 {
+		vacation.NullFields = append(vacation.NullFields, "StartTime")
+	}
+	if vacation.EndTime == 0 {
+		vacation.NullFields = append(vacation.NullFields, "EndTime")
+	}
+
+	log.Printf("[DEBUG] Updating vacation responder of %s", user)
+	err = retry(func() error {
+		_, err = gmailSvc.Users.Settings.UpdateVacation(user, vacation).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error updating vacation responder: %s", err)
+	}
+
+	d.SetId(user)
+	return resourceGmailVacationRead(d, meta)
+}
+
+func resourceGmailVacationRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	var vacation *gmail.VacationSettings
+	err = retry(func() error {
+		vacation, err = gmailSvc.Users.Settings.GetVacation(user).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Vacation responder of %q", user))
+	}
+
+	d.Set("enable_auto_reply", vacation.EnableAutoReply)
+	d.Set("response_subject", vacation.ResponseSubject)
+	d.Set("response_body_plain_text", vacation.ResponseBodyPlainText)
+	d.Set("response_body_html", vacation.ResponseBodyHtml)
+	d.Set("restrict_to_contacts", vacation.RestrictToContacts)
+	d.Set("restrict_to_domain", vacation.RestrictToDomain)
+	d.Set("start_time", gmailTimeRFC3339(vacation.StartTime))
+	d.Set("end_time", gmailTimeRFC3339(vacation.EndTime))
+
+	return nil
+}
+
+// The vacation responder is disabled when the resource is destroyed
+func resourceGmailVacationDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	user := strings.ToLower(d.Get("user").(string))
+	gmailSvc, err := config.gmailService(user, gmailSettingsScopes)
+	if err != nil {
+		return err
+	}
+
+	vacation := &gmail.VacationSettings{
+		EnableAutoReply: false,
+		ForceSendFields: []string{"EnableAutoReply"},
+	}
+	err = retry(func() error {
+		_, err = gmailSvc.Users.Settings.UpdateVacation(user, vacation).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error disabling vacation responder: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,47 @@
+package gsuite
+
+import (
+	"testing"
+)
+
+func TestGmailTimeConversion(t *testing.T) {
+	cases := map[string]int64{
+		"":                          0,
+		"2021-07-01T00:00:00Z":      1625097600000,
+		"2021-07-01T02:00:00+02:00": 1625097600000,
+	}
+	for value, expected := range cases {
+		millis, err := gmailTimeMillis(value)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", value, err)
+		}
+		if millis != expected {
+			t.Errorf("gmailTimeMillis(%q): expected %d, got %d", value, expected, millis)
+		}
+		if back := gmailTimeRFC3339(millis); !suppressEquivalentRFC3339Time("", value, back, nil) && value != back {
+			t.Errorf("gmailTimeRFC3339(%d): expected %q, got %q", millis, value, back)
+		}
+	}
+
+	if _, err := gmailTimeMillis("tomorrow"); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
+
+func TestSuppressEquivalentRFC3339Time(t *testing.T) {
+	cases := []struct {
+		old, new string
+		equal    bool
+	}{
+		// The Cloud Identity API returns UTC with fractional seconds
+		{"2021-05-31T22:00:00.000Z", "2021-06-01T00:00:00+02:00", true},
+		{"2021-05-31T22:00:00Z", "2021-05-31T22:00:00Z", true},
+		{"2021-05-31T22:00:00.000Z", "2021-06-01T00:00:00Z", false},
+		{"", "2021-06-01T00:00:00Z", false},
+	}
+	for _, c := range cases {
+		if equal := suppressEquivalentRFC3339Time("", c.old, c.new, nil); equal != c.equal {
+			t.Errorf("suppressEquivalentRFC3339Time(%q, %q): expected %t, got %t", c.old, c.new, c.equal, equal)
+		}
+	}
+}
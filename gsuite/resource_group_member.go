@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/cloudidentity/v1"
+	"google.golang.org/api/googleapi"
 )
 
 var schemaMember = map[string]*schema.Schema{
@@ -50,6 +54,17 @@ var schemaMember = map[string]*schema.Schema{
 	},
 }
 
+// Only for the member resources, the gsuite_group data source doesn't read
+// expiries
+var schemaMemberExpiry = map[string]*schema.Schema{
+	// RFC3339 timestamp after which the membership is removed
+	"expires_at": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsRFC3339Time,
+	},
+}
+
 var schemaGroup = map[string]*schema.Schema{
 	"group": &schema.Schema{
 		Type:     schema.TypeString,
@@ -60,7 +75,15 @@ var schemaGroup = map[string]*schema.Schema{
 	},
 }
 
-var schemaMembership = mergeSchemas(schemaGroup, schemaMember)
+var schemaMemberExpired = map[string]*schema.Schema{
+	// Whether the membership was removed because it expired
+	"expired": &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	},
+}
+
+var schemaMembership = mergeSchemas(mergeSchemas(mergeSchemas(schemaGroup, schemaMember), schemaMemberExpiry), schemaMemberExpired)
 
 func resourceGroupMember() *schema.Resource {
 	return &schema.Resource{
@@ -72,10 +95,105 @@ func resourceGroupMember() *schema.Resource {
 			State: resourceGroupMemberImporter,
 		},
 
+		CustomizeDiff: resourceGroupMemberCustomizeDiff,
+
 		Schema: schemaMembership,
 	}
 }
 
+// memberExpired reports whether expiresAt (RFC3339, may be empty) is before now.
+func memberExpired(expiresAt string, now time.Time) bool {
+	if expiresAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false
+	}
+	return !t.After(now)
+}
+
+// Plans the removal of the membership once expires_at has passed, or its
+// re-creation when expires_at is moved to the future again.
+func resourceGroupMemberCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("expires_at") {
+		return nil
+	}
+
+	expired := memberExpired(d.Get("expires_at").(string), time.Now())
+	if d.Id() == "" {
+		if expired {
+			return fmt.Errorf("expires_at %s is in the past", d.Get("expires_at").(string))
+		}
+		return nil
+	}
+
+	if expired != d.Get("expired").(bool) {
+		return d.SetNew("expired", expired)
+	}
+	return nil
+}
+
+// setNativeMemberExpiry sets (or clears, with an empty expiresAt) the expiry of
+// the MEMBER role of a membership with the Cloud Identity API, which removes
+// the member at that time even when Terraform does not run.
+func setNativeMemberExpiry(config *Config, groupEmail, email, expiresAt string) error {
+	var group *cloudidentity.LookupGroupNameResponse
+	var err error
+	err = retry(func() error {
+		group, err = config.cloudIdentity.Groups.Lookup().GroupKeyId(groupEmail).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return err
+	}
+
+	// Retrying for 404's, the membership may not have propagated yet
+	var membership *cloudidentity.LookupMembershipNameResponse
+	err = retryNotFound(func() error {
+		membership, err = config.cloudIdentity.Groups.Memberships.Lookup(group.Name).MemberKeyId(email).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return err
+	}
+
+	request := &cloudidentity.ModifyMembershipRolesRequest{
+		UpdateRolesParams: []*cloudidentity.UpdateMembershipRolesParams{
+			{
+				FieldMask: "expiry_detail.expire_time",
+				MembershipRole: &cloudidentity.MembershipRole{
+					Name:         "MEMBER",
+					ExpiryDetail: &cloudidentity.ExpiryDetail{ExpireTime: expiresAt},
+				},
+			},
+		},
+	}
+	return cloudIdentityModifyMembershipRoles(config, membership.Name, request)
+}
+
+// updateMemberExpiry sets the expiry natively where possible; otherwise the
+// provider removes the membership once it has expired. Only a missing
+// permission, e.g. without the cloud-identity.groups scope, falls back to the
+// provider.
+func updateMemberExpiry(config *Config, groupEmail, email, role, expiresAt string) error {
+	if strings.ToUpper(role) != "MEMBER" {
+		log.Printf("[DEBUG] Only MEMBER roles can expire natively, expiry of %s is enforced by the provider", email)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Setting expiry of member %s to %q", email, expiresAt)
+	err := setNativeMemberExpiry(config, groupEmail, email, expiresAt)
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 403 {
+		log.Printf("[WARN] Not allowed to set the expiry of member %s with the Cloud Identity API, it is enforced by the provider instead: %s", email, err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error setting the expiry of member %s: %s", email, err)
+	}
+	return nil
+}
+
 func resourceGroupMemberCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -137,11 +255,42 @@ func resourceGroupMemberCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("[ERROR] Taking too long to create this group member: %s", err)
 	}
 
+	if expiresAt := d.Get("expires_at").(string); expiresAt != "" {
+		if err = updateMemberExpiry(config, group, groupMember.Email, groupMember.Role, expiresAt); err != nil {
+			return err
+		}
+	}
+
 	return resourceGroupMemberRead(d, meta)
 }
 
 func resourceGroupMemberUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
+	group := strings.ToLower(d.Get("group").(string))
+	email := strings.ToLower(d.Get("email").(string))
+
+	if d.HasChange("expired") {
+		if d.Get("expired").(bool) {
+			log.Printf("[INFO] Group member %s expired at %s, removing it", email, d.Get("expires_at").(string))
+			if err := deleteMember(d.Id(), group, config); err != nil {
+				return err
+			}
+			return resourceGroupMemberRead(d, meta)
+		}
+
+		log.Printf("[INFO] Expiry of group member %s moved to %s, adding it again", email, d.Get("expires_at").(string))
+		return resourceGroupMemberCreate(d, meta)
+	}
+
+	if d.HasChange("expires_at") {
+		if err := updateMemberExpiry(config, group, email, d.Get("role").(string), d.Get("expires_at").(string)); err != nil {
+			return err
+		}
+	}
+
+	if !d.HasChange("email") && !d.HasChange("role") {
+		return resourceGroupMemberRead(d, meta)
+	}
 
 	groupMember := &directory.Member{}
 	nullFields := []string{}
@@ -163,7 +312,7 @@ func resourceGroupMemberUpdate(d *schema.ResourceData, meta interface{}) error {
 	var updatedGroupMember *directory.Member
 	var err error
 	err = retry(func() error {
-		updatedGroupMember, err = config.directory.Members.Patch(strings.ToLower(d.Get("group").(string)), d.Id(), groupMember).Do()
+		updatedGroupMember, err = config.directory.Members.Patch(group, d.Id(), groupMember).Do()
 		return err
 	}, config.TimeoutMinutes)
 
@@ -186,10 +335,17 @@ func resourceGroupMemberRead(d *schema.ResourceData, meta interface{}) error {
 	}, config.TimeoutMinutes)
 
 	if err != nil {
+		// Expired members are kept in the state, as removed, until expires_at changes
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 && memberExpired(d.Get("expires_at").(string), time.Now()) {
+			log.Printf("[DEBUG] Group member %q expired", d.Get("email").(string))
+			d.Set("expired", true)
+			return nil
+		}
 		return handleNotFoundError(err, d, fmt.Sprintf("Group member %q", d.Get("email").(string)))
 	}
 
 	d.SetId(groupMember.Id)
+	d.Set("expired", false)
 	d.Set("role", strings.ToUpper(groupMember.Role))
 	d.Set("email", strings.ToLower(groupMember.Email))
 	d.Set("etag", groupMember.Etag)
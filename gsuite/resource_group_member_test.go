@@ -0,0 +1,42 @@
+package gsuite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMemberExpired(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]bool{
+		"":                          false,
+		"not a time":                false,
+		"2021-06-01T11:59:59Z":      true,
+		"2021-06-01T12:00:00Z":      true,
+		"2021-06-01T12:00:01Z":      false,
+		"2021-06-01T13:30:00+02:00": true,
+		"2022-01-01T00:00:00Z":      false,
+	}
+	for expiresAt, expected := range cases {
+		if got := memberExpired(expiresAt, now); got != expected {
+			t.Errorf("memberExpired(%q): expected %t, got %t", expiresAt, expected, got)
+		}
+	}
+}
+
+func TestExpiredMembers(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	members := []map[string]interface{}{
+		{"email": "Temp@domain.ext", "role": "MEMBER", "expires_at": "2021-05-31T00:00:00Z"},
+		{"email": "later@domain.ext", "role": "MEMBER", "expires_at": "2021-07-01T00:00:00Z"},
+		{"email": "owner@domain.ext", "role": "OWNER", "expires_at": ""},
+		{"email": "member@domain.ext", "role": "MEMBER"},
+	}
+
+	expected := []string{"temp@domain.ext"}
+	if got := expiredMembers(members, now); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	directory "google.golang.org/api/admin/directory/v1"
@@ -34,6 +35,8 @@ func resourceGroupMembers() *schema.Resource {
 			State: resourceGroupMembersImporter,
 		},
 
+		CustomizeDiff: resourceGroupMembersCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"group_email": {
 				Type:     schema.TypeString,
@@ -47,13 +50,51 @@ func resourceGroupMembers() *schema.Resource {
 				Type:     schema.TypeSet,
 				Required: true,
 				Elem: &schema.Resource{
-					Schema: schemaGroupMembers,
+					Schema: mergeSchemas(schemaGroupMembers, schemaMemberExpiry),
 				},
 			},
+			// Emails of the members which were removed because they expired
+			"expired_members": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
+// expiredMembers returns the emails of the members whose expires_at has passed.
+func expiredMembers(members []map[string]interface{}, now time.Time) []string {
+	expired := []string{}
+	for _, member := range members {
+		if expiresAt, ok := member["expires_at"].(string); ok && memberExpired(expiresAt, now) {
+			expired = append(expired, strings.ToLower(member["email"].(string)))
+		}
+	}
+	return expired
+}
+
+// Plans the removal of members once their expires_at has passed
+func resourceGroupMembersCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("member") {
+		return nil
+	}
+
+	var members []map[string]interface{}
+	for _, rawMember := range d.Get("member").(*schema.Set).List() {
+		members = append(members, rawMember.(map[string]interface{}))
+	}
+
+	expired := schema.NewSet(schema.HashString, nil)
+	for _, email := range expiredMembers(members, time.Now()) {
+		expired.Add(email)
+	}
+	if !expired.Equal(d.Get("expired_members").(*schema.Set)) {
+		return d.SetNew("expired_members", expired.List())
+	}
+	return nil
+}
+
 func resourceGroupMembersRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG]: Reading gsuite_group_members")
 	config := meta.(*Config)
@@ -66,8 +107,29 @@ func resourceGroupMembersRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	cfgMembers := membersToCfg(members)
+
+	// Keep the expiry of members from the state, and keep expired members that
+	// were removed so they are not planned to be added again.
+	current := map[string]map[string]interface{}{}
+	for _, member := range cfgMembers {
+		current[strings.ToLower(member["email"].(string))] = member
+	}
+	expired := []string{}
+	for _, member := range resourceMembers(d) {
+		email := strings.ToLower(member["email"].(string))
+		expiresAt, _ := member["expires_at"].(string)
+		if apiMember, ok := current[email]; ok {
+			apiMember["expires_at"] = expiresAt
+		} else if memberExpired(expiresAt, time.Now()) {
+			cfgMembers = append(cfgMembers, member)
+			expired = append(expired, email)
+		}
+	}
+
 	d.Set("group_email", strings.ToLower(groupEmail))
-	d.Set("member", membersToCfg(members))
+	d.Set("member", cfgMembers)
+	d.Set("expired_members", expired)
 	return nil
 }
 
@@ -178,6 +240,26 @@ func reconcileMembers(d *schema.ResourceData, cfgMembers, apiMembers []map[strin
 	apiMap := m(apiMembers)
 	log.Println("[DEBUG] Member in API: ", apiMap)
 
+	// Expired members are handled as if they were not in the config
+	for _, email := range expiredMembers(cfgMembers, time.Now()) {
+		log.Printf("[INFO] Member %s has expired", email)
+		delete(cfgMap, email)
+	}
+
+	// Expiry before this change, to only update the native expiry when needed
+	oldExpiry := map[string]string{}
+	if o, _ := d.GetChange("member"); o != nil {
+		for _, rawMember := range o.(*schema.Set).List() {
+			member := rawMember.(map[string]interface{})
+			expiresAt, _ := member["expires_at"].(string)
+			oldExpiry[strings.ToLower(member["email"].(string))] = expiresAt
+		}
+	}
+	activeMembers := make(map[string]map[string]interface{}, len(cfgMap))
+	for email, member := range cfgMap {
+		activeMembers[email] = member
+	}
+
 	var cfgRole, apiRole string
 
 	for k, apiMember := range apiMap {
@@ -227,6 +309,14 @@ func reconcileMembers(d *schema.ResourceData, cfgMembers, apiMembers []map[strin
 			return err
 		}
 	}
+
+	for email, member := range activeMembers {
+		if expiresAt, _ := member["expires_at"].(string); expiresAt != oldExpiry[email] {
+			if err := updateMemberExpiry(config, gid, email, member["role"].(string), expiresAt); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
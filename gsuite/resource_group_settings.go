@@ -3,14 +3,155 @@ package gsuite
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 
+	"google.golang.org/api/googleapi"
 	groupSettings "google.golang.org/api/groupssettings/v1"
 )
 
+// groupSettingsFields maps every attribute on the name of its field in
+// groupSettings.Groups. The API represents booleans as "true"/"false"
+// strings, those are converted by the schema type of the attribute. See
+// flattenGroupSettings and expandGroupSettings.
+var groupSettingsFields = map[string]string{
+	"allow_external_members":                         "AllowExternalMembers",
+	"allow_google_communication":                     "AllowGoogleCommunication",
+	"allow_web_posting":                              "AllowWebPosting",
+	"archive_only":                                   "ArchiveOnly",
+	"custom_footer_text":                             "CustomFooterText",
+	"custom_reply_to":                                "CustomReplyTo",
+	"custom_roles_enabled_for_settings_to_be_merged": "CustomRolesEnabledForSettingsToBeMerged",
+	"default_message_deny_notification_text":         "DefaultMessageDenyNotificationText",
+	"description":                                    "Description",
+	"enable_collaborative_inbox":                     "EnableCollaborativeInbox",
+	"favorite_replies_on_top":                        "FavoriteRepliesOnTop",
+	"include_custom_footer":                          "IncludeCustomFooter",
+	"include_in_global_address_list":                 "IncludeInGlobalAddressList",
+	"is_archived":                                    "IsArchived",
+	"kind":                                           "Kind",
+	"max_message_bytes":                              "MaxMessageBytes",
+	"members_can_post_as_the_group":                  "MembersCanPostAsTheGroup",
+	"message_display_font":                           "MessageDisplayFont",
+	"message_moderation_level":                       "MessageModerationLevel",
+	"name":                                           "Name",
+	"primary_language":                               "PrimaryLanguage",
+	"reply_to":                                       "ReplyTo",
+	"send_message_deny_notification":                 "SendMessageDenyNotification",
+	"show_in_group_directory":                        "ShowInGroupDirectory",
+	"spam_moderation_level":                          "SpamModerationLevel",
+	"who_can_add":                                    "WhoCanAdd",
+	"who_can_add_references":                         "WhoCanAddReferences",
+	"who_can_approve_members":                        "WhoCanApproveMembers",
+	"who_can_approve_messages":                       "WhoCanApproveMessages",
+	"who_can_assign_topics":                          "WhoCanAssignTopics",
+	"who_can_assist_content":                         "WhoCanAssistContent",
+	"who_can_ban_users":                              "WhoCanBanUsers",
+	"who_can_contact_owner":                          "WhoCanContactOwner",
+	"who_can_delete_any_post":                        "WhoCanDeleteAnyPost",
+	"who_can_delete_topics":                          "WhoCanDeleteTopics",
+	"who_can_discover_group":                         "WhoCanDiscoverGroup",
+	"who_can_enter_free_form_tags":                   "WhoCanEnterFreeFormTags",
+	"who_can_hide_abuse":                             "WhoCanHideAbuse",
+	"who_can_invite":                                 "WhoCanInvite",
+	"who_can_join":                                   "WhoCanJoin",
+	"who_can_leave_group":                            "WhoCanLeaveGroup",
+	"who_can_lock_topics":                            "WhoCanLockTopics",
+	"who_can_make_topics_sticky":                     "WhoCanMakeTopicsSticky",
+	"who_can_mark_duplicate":                         "WhoCanMarkDuplicate",
+	"who_can_mark_favorite_reply_on_any_topic":       "WhoCanMarkFavoriteReplyOnAnyTopic",
+	"who_can_mark_favorite_reply_on_own_topic":       "WhoCanMarkFavoriteReplyOnOwnTopic",
+	"who_can_mark_no_response_needed":                "WhoCanMarkNoResponseNeeded",
+	"who_can_moderate_content":                       "WhoCanModerateContent",
+	"who_can_moderate_members":                       "WhoCanModerateMembers",
+	"who_can_modify_members":                         "WhoCanModifyMembers",
+	"who_can_modify_tags_and_categories":             "WhoCanModifyTagsAndCategories",
+	"who_can_move_topics_in":                         "WhoCanMoveTopicsIn",
+	"who_can_move_topics_out":                        "WhoCanMoveTopicsOut",
+	"who_can_post_announcements":                     "WhoCanPostAnnouncements",
+	"who_can_post_message":                           "WhoCanPostMessage",
+	"who_can_take_topics":                            "WhoCanTakeTopics",
+	"who_can_unassign_topic":                         "WhoCanUnassignTopic",
+	"who_can_unmark_favorite_reply_on_any_topic":     "WhoCanUnmarkFavoriteReplyOnAnyTopic",
+	"who_can_view_group":                             "WhoCanViewGroup",
+	"who_can_view_membership":                        "WhoCanViewMembership",
+}
+
+// groupSettingsPresets are bundles of settings for common kinds of groups.
+// Keep website/docs/r/group_settings.md in sync.
+var groupSettingsPresets = map[string]map[string]interface{}{
+	"announcement_list": {
+		"allow_external_members":         false,
+		"allow_web_posting":              true,
+		"include_in_global_address_list": true,
+		"members_can_post_as_the_group":  false,
+		"message_moderation_level":       "MODERATE_NONE",
+		"reply_to":                       "REPLY_TO_SENDER",
+		"who_can_contact_owner":          "ALL_IN_DOMAIN_CAN_CONTACT",
+		"who_can_discover_group":         "ALL_IN_DOMAIN_CAN_DISCOVER",
+		"who_can_join":                   "ALL_IN_DOMAIN_CAN_JOIN",
+		"who_can_post_message":           "ALL_MANAGERS_CAN_POST",
+		"who_can_view_group":             "ALL_IN_DOMAIN_CAN_VIEW",
+		"who_can_view_membership":        "ALL_MANAGERS_CAN_VIEW",
+	},
+	"team_discussion": {
+		"allow_external_members":   false,
+		"allow_web_posting":        true,
+		"message_moderation_level": "MODERATE_NONE",
+		"reply_to":                 "REPLY_TO_LIST",
+		"spam_moderation_level":    "MODERATE",
+		"who_can_discover_group":   "ALL_IN_DOMAIN_CAN_DISCOVER",
+		"who_can_join":             "CAN_REQUEST_TO_JOIN",
+		"who_can_moderate_content": "OWNERS_AND_MANAGERS",
+		"who_can_moderate_members": "OWNERS_AND_MANAGERS",
+		"who_can_post_message":     "ALL_MEMBERS_CAN_POST",
+		"who_can_view_group":       "ALL_MEMBERS_CAN_VIEW",
+		"who_can_view_membership":  "ALL_MEMBERS_CAN_VIEW",
+	},
+	"external_collaboration": {
+		"allow_external_members":   true,
+		"message_moderation_level": "MODERATE_NONE",
+		"reply_to":                 "REPLY_TO_LIST",
+		"spam_moderation_level":    "MODERATE",
+		"who_can_contact_owner":    "ALL_MEMBERS_CAN_CONTACT",
+		"who_can_discover_group":   "ALL_MEMBERS_CAN_DISCOVER",
+		"who_can_join":             "INVITED_CAN_JOIN",
+		"who_can_moderate_members": "OWNERS_AND_MANAGERS",
+		"who_can_post_message":     "ALL_MEMBERS_CAN_POST",
+		"who_can_view_group":       "ALL_MEMBERS_CAN_VIEW",
+		"who_can_view_membership":  "ALL_MEMBERS_CAN_VIEW",
+	},
+	"ticket_inbox": {
+		"allow_external_members":     false,
+		"enable_collaborative_inbox": true,
+		"message_moderation_level":   "MODERATE_NONE",
+		"reply_to":                   "REPLY_TO_SENDER",
+		"spam_moderation_level":      "MODERATE",
+		"who_can_assist_content":     "ALL_MEMBERS",
+		"who_can_contact_owner":      "ANYONE_CAN_CONTACT",
+		"who_can_discover_group":     "ALL_MEMBERS_CAN_DISCOVER",
+		"who_can_join":               "INVITED_CAN_JOIN",
+		"who_can_post_message":       "ANYONE_CAN_POST",
+		"who_can_view_group":         "ALL_MEMBERS_CAN_VIEW",
+		"who_can_view_membership":    "ALL_MANAGERS_CAN_VIEW",
+	},
+}
+
+func groupSettingsPresetNames() []string {
+	names := []string{}
+	for name := range groupSettingsPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func resourceGroupSettings() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGroupSettingsCreate,
@@ -21,9 +162,15 @@ func resourceGroupSettings() *schema.Resource {
 			State: resourceGroupSettingsImporter,
 		},
 
+		SchemaVersion: 1,
+		MigrateState:  resourceGroupSettingsMigrateState,
+
+		CustomizeDiff: resourceGroupSettingsPresetDiff,
+
 		Schema: map[string]*schema.Schema{
 			"is_archived": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
+				Optional: true,
 				Computed: true,
 			},
 			"kind": {
@@ -43,690 +190,645 @@ func resourceGroupSettings() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validateEmail,
 			},
+			"preset": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(groupSettingsPresetNames(), false),
+			},
+			// Attributes of the preset overridden in the configuration, kept
+			// across plans as the diff can't tell them from unset attributes
+			"preset_overrides": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Values of the preset which differ from the live group
+			"preset_values": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Values of the managed attributes before they were managed,
+			// restored on delete
+			"original_values": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"allow_external_members": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Computed: true,
 			},
 			"allow_google_communication": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Removed:  "Removed.",
+				Computed: true,
 			},
 			"allow_web_posting": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
+				Computed: true,
 			},
 			"archive_only": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Computed: true,
 			},
 			"custom_footer_text": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"custom_reply_to": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
-			"favorite_replies_on_top": {
+			"custom_roles_enabled_for_settings_to_be_merged": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"default_message_deny_notification_text": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "true",
+				Computed: true,
+			},
+			"enable_collaborative_inbox": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"favorite_replies_on_top": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
 			},
 			"include_custom_footer": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Computed: true,
 			},
 			"include_in_global_address_list": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
+				Computed: true,
 			},
 			"max_message_bytes": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				Removed:  "Removed.",
+				Computed: true,
 			},
 			"members_can_post_as_the_group": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Computed: true,
 			},
 			"message_display_font": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Removed.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"DEFAULT_FONT", "FIXED_WIDTH_FONT", ""}, false),
 			},
 			"message_moderation_level": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"MODERATE_ALL_MESSAGES", "MODERATE_NON_MEMBERS", "MODERATE_NEW_MEMBERS", "MODERATE_NONE", ""}, false),
-				Default:      "MODERATE_NONE",
 			},
 			"primary_language": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"reply_to": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"REPLY_TO_CUSTOM", "REPLY_TO_SENDER", "REPLY_TO_LIST", "REPLY_TO_OWNER", "REPLY_TO_IGNORE", "REPLY_TO_MANAGERS", ""}, false),
-				Default:      "REPLY_TO_IGNORE",
 			},
 			"send_message_deny_notification": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "false",
+				Computed: true,
 			},
 			"show_in_group_directory": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_discover_group property instead.",
+				Type:       schema.TypeBool,
+				Optional:   true,
+				Computed:   true,
+				Deprecated: "Use the who_can_discover_group property instead.",
 			},
 			"spam_moderation_level": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ALLOW", "MODERATE", "SILENTLY_MODERATE", "REJECT", ""}, false),
-				Default:      "MODERATE",
 			},
 			"who_can_add": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_members property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_members property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MANAGERS_CAN_ADD", "ALL_OWNERS_CAN_ADD", "ALL_MEMBERS_CAN_ADD", "NONE_CAN_ADD", ""}, false),
 			},
 			"who_can_add_references": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Removed.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_approve_members": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ALL_OWNERS_CAN_APPROVE", "ALL_MANAGERS_CAN_APPROVE", "ALL_MEMBERS_CAN_APPROVE", "NONE_CAN_APPROVE", ""}, false),
-				Default:      "ALL_MANAGERS_CAN_APPROVE",
 			},
 			"who_can_approve_messages": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_OWNERS_CAN_APPROVE", "ALL_MANAGERS_CAN_APPROVE", "ALL_MEMBERS_CAN_APPROVE", "NONE_CAN_APPROVE", ""}, false),
 			},
 			"who_can_assign_topics": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_assist_content": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"NONE", "OWNERS_ONLY", "MANAGERS_ONLY", "OWNERS_AND_MANAGERS", "ALL_MEMBERS", ""}, false),
-				Default:      "NONE",
 			},
 			"who_can_ban_users": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_members property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_members property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_contact_owner": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ANYONE_CAN_CONTACT", "ALL_IN_DOMAIN_CAN_CONTACT", "ALL_MEMBERS_CAN_CONTACT", "ALL_MANAGERS_CAN_CONTACT", "ALL_OWNERS_CAN_CONTACT", ""}, false),
-				Default:      "ANYONE_CAN_CONTACT",
 			},
 			"who_can_delete_any_post": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_delete_topics": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_discover_group": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS_CAN_DISCOVER", "ALL_IN_DOMAIN_CAN_DISCOVER", "ANYONE_CAN_DISCOVER", ""}, false),
-				Default:      "ALL_MEMBERS_CAN_DISCOVER",
 			},
 			"who_can_enter_free_form_tags": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_hide_abuse": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_invite": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_members property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_members property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS_CAN_INVITE", "ALL_MANAGERS_CAN_INVITE", "ALL_OWNERS_CAN_INVITE", "NONE_CAN_INVITE", ""}, false),
 			},
 			"who_can_join": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ANYONE_CAN_JOIN", "ALL_IN_DOMAIN_CAN_JOIN", "INVITED_CAN_JOIN", "CAN_REQUEST_TO_JOIN", ""}, false),
-				Default:      "CAN_REQUEST_TO_JOIN",
 			},
 			"who_can_leave_group": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ALL_MANAGERS_CAN_LEAVE", "ALL_OWNERS_CAN_LEAVE", "ALL_MEMBERS_CAN_LEAVE", "NONE_CAN_LEAVE", ""}, false),
-				Default:      "ALL_MEMBERS_CAN_LEAVE",
 			},
 			"who_can_lock_topics": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_make_topics_sticky": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_mark_duplicate": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_mark_favorite_reply_on_any_topic": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_mark_favorite_reply_on_own_topic": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_mark_no_response_needed": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_moderate_content": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"NONE", "OWNERS_ONLY", "OWNERS_AND_MANAGERS", "ALL_MEMBERS", ""}, false),
-				Default:      "OWNERS_AND_MANAGERS",
 			},
 			"who_can_moderate_members": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"NONE", "OWNERS_ONLY", "OWNERS_AND_MANAGERS", "ALL_MEMBERS", ""}, false),
-				Default:      "OWNERS_AND_MANAGERS",
 			},
 			"who_can_modify_members": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_members property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_members property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_modify_tags_and_categories": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_move_topics_in": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_move_topics_out": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_post_announcements": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_moderate_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_moderate_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_post_message": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"NONE_CAN_POST", "ALL_MANAGERS_CAN_POST", "ALL_MEMBERS_CAN_POST", "ALL_OWNERS_CAN_POST", "ALL_IN_DOMAIN_CAN_POST", "ANYONE_CAN_POST", ""}, false),
-				Default:      "ANYONE_CAN_POST",
 			},
 			"who_can_take_topics": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_unassign_topic": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_unmark_favorite_reply_on_any_topic": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Removed:  "Use the who_can_assist_content property instead.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Deprecated:   "Use the who_can_assist_content property instead.",
+				ValidateFunc: validation.StringInSlice([]string{"ALL_MEMBERS", "OWNERS_AND_MANAGERS", "MANAGERS_ONLY", "OWNERS_ONLY", "NONE", ""}, false),
 			},
 			"who_can_view_group": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ANYONE_CAN_VIEW", "ALL_IN_DOMAIN_CAN_VIEW", "ALL_MEMBERS_CAN_VIEW", "ALL_MANAGERS_CAN_VIEW", "ALL_OWNERS_CAN_VIEW", ""}, false),
-				Default:      "ALL_MEMBERS_CAN_VIEW",
 			},
 			"who_can_view_membership": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ALL_IN_DOMAIN_CAN_VIEW", "ALL_MEMBERS_CAN_VIEW", "ALL_MANAGERS_CAN_VIEW", "ALL_OWNERS_CAN_VIEW", ""}, false),
-				Default:      "ALL_MEMBERS_CAN_VIEW",
 			},
 		},
 	}
 }
 
-func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
-
-	// GroupSettings
-	groupSetting := &groupSettings.Groups{
-		Email: strings.ToLower(d.Get("email").(string)),
-	}
-	if v, ok := d.GetOk("allow_external_members"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "allow_external_members", v.(string))
-		groupSetting.AllowExternalMembers = v.(string)
-	}
-	if v, ok := d.GetOk("allow_web_posting"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "allow_web_posting", v.(string))
-		groupSetting.AllowWebPosting = v.(string)
-	}
-	if v, ok := d.GetOk("archive_only"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "archive_only", v.(string))
-		groupSetting.ArchiveOnly = v.(string)
-	}
-	if v, ok := d.GetOk("custom_footer_text"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "custom_footer_text", v.(string))
-		groupSetting.CustomFooterText = v.(string)
-	}
-	if v, ok := d.GetOk("custom_reply_to"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "custom_reply_to", v.(string))
-		groupSetting.CustomReplyTo = v.(string)
-	}
-	if v, ok := d.GetOk("description"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "description", v.(string))
-		groupSetting.Description = v.(string)
-	}
-	if v, ok := d.GetOk("favorite_replies_on_top"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "favorite_replies_on_top", v.(string))
-		groupSetting.FavoriteRepliesOnTop = v.(string)
-	}
-	if v, ok := d.GetOk("include_custom_footer"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "include_custom_footer", v.(string))
-		groupSetting.IncludeCustomFooter = v.(string)
-	}
-	if v, ok := d.GetOk("include_in_global_address_list"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "include_in_global_address_list", v.(string))
-		groupSetting.IncludeInGlobalAddressList = v.(string)
-	}
-	if v, ok := d.GetOk("members_can_post_as_the_group"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "members_can_post_as_the_group", v.(string))
-		groupSetting.MembersCanPostAsTheGroup = v.(string)
-	}
-	if v, ok := d.GetOk("message_moderation_level"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "message_moderation_level", v.(string))
-		groupSetting.MessageModerationLevel = v.(string)
-	}
-	if v, ok := d.GetOk("primary_language"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "primary_language", v.(string))
-		groupSetting.PrimaryLanguage = v.(string)
-	}
-	if v, ok := d.GetOk("reply_to"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "reply_to", v.(string))
-		groupSetting.ReplyTo = v.(string)
-	}
-	if v, ok := d.GetOk("send_message_deny_notification"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "send_message_deny_notification", v.(string))
-		groupSetting.SendMessageDenyNotification = v.(string)
-	}
-	if v, ok := d.GetOk("spam_moderation_level"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "spam_moderation_level", v.(string))
-		groupSetting.SpamModerationLevel = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_approve_members"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_approve_members", v.(string))
-		groupSetting.WhoCanApproveMembers = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_assist_content"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_assist_content", v.(string))
-		groupSetting.WhoCanAssistContent = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_contact_owner"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_contact_owner", v.(string))
-		groupSetting.WhoCanContactOwner = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_discover_group"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_discover_group", v.(string))
-		groupSetting.WhoCanDiscoverGroup = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_join"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_join", v.(string))
-		groupSetting.WhoCanJoin = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_leave_group"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_leave_group", v.(string))
-		groupSetting.WhoCanLeaveGroup = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_moderate_content"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_moderate_content", v.(string))
-		groupSetting.WhoCanModerateContent = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_moderate_members"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_moderate_members", v.(string))
-		groupSetting.WhoCanModerateMembers = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_post_message"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_post_message", v.(string))
-		groupSetting.WhoCanPostMessage = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_view_group"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_view_group", v.(string))
-		groupSetting.WhoCanViewGroup = v.(string)
-	}
-	if v, ok := d.GetOk("who_can_view_membership"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "who_can_view_membership", v.(string))
-		groupSetting.WhoCanViewMembership = v.(string)
+// resourceGroupSettingsMigrateState converts the "true"/"false" strings of
+// the boolean attributes, the API also returned empty strings for unset
+// values.
+func resourceGroupSettingsMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
 	}
 
-	var err error
-	err = retry(func() error {
-		_, err = config.groupSettings.Groups.Update(d.Get("email").(string), groupSetting).Do()
-		return err
-	}, config.TimeoutMinutes)
-	if err != nil {
-		return fmt.Errorf("[ERROR] Something went wrong while updating group settings for '%s': %s", d.Get("email").(string), err)
+	switch v {
+	case 0:
+		log.Println("[INFO] Found G Suite Group Settings State v0; migrating to v1")
+		for attribute, s := range resourceGroupSettings().Schema {
+			value, ok := is.Attributes[attribute]
+			if !ok || s.Type != schema.TypeBool {
+				continue
+			}
+			is.Attributes[attribute] = fmt.Sprint(strings.EqualFold(value, "true"))
+		}
+		return is, nil
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
 	}
-
-	return resourceGroupSettingsRead(d, meta)
 }
 
-func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*Config)
+// resourceGroupSettingsPresetDiff plans the values of the preset for the
+// attributes that are not overridden. Configured attributes which differ from
+// the preset are listed in `preset_overrides`, the values of the preset which
+// differ from the live group in `preset_values`.
+func resourceGroupSettingsPresetDiff(d *schema.ResourceDiff, meta interface{}) error {
+	preset := groupSettingsPresets[d.Get("preset").(string)]
 
-	// GroupSettings
-	nullFields := []string{}
-	groupSetting := &groupSettings.Groups{
-		Email: strings.ToLower(d.Get("email").(string)),
-	}
-	if d.HasChange("allow_external_members") {
-		if v, ok := d.GetOk("allow_external_members"); ok {
-			log.Printf("[DEBUG] Updating group allow external members: %s", v.(string))
-			groupSetting.AllowExternalMembers = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting AllowExternalMembers")
-			groupSetting.AllowExternalMembers = ""
-			nullFields = append(nullFields, "AllowExternalMembers")
-		}
-	}
-	if d.HasChange("allow_web_posting") {
-		if v, ok := d.GetOk("allow_web_posting"); ok {
-			log.Printf("[DEBUG] Updating allow_web_posting: %s", v.(string))
-			groupSetting.AllowWebPosting = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting AllowWebPosting")
-			groupSetting.AllowWebPosting = ""
-			nullFields = append(nullFields, "AllowWebPosting")
-		}
-	}
-	if d.HasChange("archive_only") {
-		if v, ok := d.GetOk("archive_only"); ok {
-			log.Printf("[DEBUG] Updating archive_only: %s", v.(string))
-			groupSetting.ArchiveOnly = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting ArchiveOnly")
-			groupSetting.ArchiveOnly = ""
-			nullFields = append(nullFields, "ArchiveOnly")
-		}
-	}
-	if d.HasChange("custom_footer_text") {
-		if v, ok := d.GetOk("custom_footer_text"); ok {
-			log.Printf("[DEBUG] Updating custom_footer_text: %s", v.(string))
-			groupSetting.CustomFooterText = v.(string)
+	// Optional+Computed attributes which are not configured keep their
+	// state, so on existing groups only changed attributes are known to be
+	// configured. Overrides of the last apply stay overrides.
+	previous, _ := d.GetChange("preset_overrides")
+	overrides := previous.(*schema.Set)
+
+	overridden := []interface{}{}
+	values := map[string]interface{}{}
+	for attribute, value := range preset {
+		var configured bool
+		if d.Id() == "" {
+			// Unconfigured attributes of new groups are computed
+			configured = d.NewValueKnown(attribute)
 		} else {
-			log.Printf("[DEBUG] Removing groupSetting CustomFooterText")
-			groupSetting.CustomFooterText = ""
-			nullFields = append(nullFields, "CustomFooterText")
+			configured = d.HasChange(attribute) || overrides.Contains(attribute)
 		}
-	}
-	if d.HasChange("custom_reply_to") {
-		if v, ok := d.GetOk("custom_reply_to"); ok {
-			log.Printf("[DEBUG] Updating custom_reply_to: %s", v.(string))
-			groupSetting.CustomReplyTo = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting CustomReplyTo")
-			groupSetting.CustomReplyTo = ""
-			nullFields = append(nullFields, "CustomReplyTo")
+		if configured {
+			if !d.NewValueKnown(attribute) || d.Get(attribute) != value {
+				overridden = append(overridden, attribute)
+			}
+			continue
 		}
-	}
-	if d.HasChange("description") {
-		if v, ok := d.GetOk("description"); ok {
-			log.Printf("[DEBUG] Updating description: %s", v.(string))
-			groupSetting.Description = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting Description")
-			groupSetting.Description = ""
-			nullFields = append(nullFields, "Description")
+
+		// The live value is only known for existing groups
+		if d.Id() != "" && d.Get(attribute) == value {
+			continue
 		}
-	}
-	if d.HasChange("favorite_replies_on_top") {
-		if v, ok := d.GetOk("favorite_replies_on_top"); ok {
-			log.Printf("[DEBUG] Updating favorite_replies_on_top: %s", v.(string))
-			groupSetting.FavoriteRepliesOnTop = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting FavoriteRepliesOnTop")
-			groupSetting.FavoriteRepliesOnTop = ""
-			nullFields = append(nullFields, "FavoriteRepliesOnTop")
+
+		values[attribute] = fmt.Sprint(value)
+		log.Printf("[DEBUG] Setting %s from preset: %v", attribute, value)
+		if err := d.SetNew(attribute, value); err != nil {
+			return err
 		}
 	}
-	if d.HasChange("include_custom_footer") {
-		if v, ok := d.GetOk("include_custom_footer"); ok {
-			log.Printf("[DEBUG] Updating include_custom_footer: %s", v.(string))
-			groupSetting.IncludeCustomFooter = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting IncludeCustomFooter")
-			groupSetting.IncludeCustomFooter = ""
-			nullFields = append(nullFields, "IncludeCustomFooter")
-		}
+
+	if err := d.SetNew("preset_overrides", overridden); err != nil {
+		return err
 	}
-	if d.HasChange("include_in_global_address_list") {
-		if v, ok := d.GetOk("include_in_global_address_list"); ok {
-			log.Printf("[DEBUG] Updating include_in_global_address_list: %s", v.(string))
-			groupSetting.IncludeInGlobalAddressList = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting IncludeInGlobalAddressList")
-			groupSetting.IncludeInGlobalAddressList = ""
-			nullFields = append(nullFields, "IncludeInGlobalAddressList")
-		}
+
+	// Keep the values of the last apply when the group matches the preset,
+	// so they don't show up as a change on every plan
+	if len(values) == 0 && !d.HasChange("preset") {
+		return nil
 	}
-	if d.HasChange("members_can_post_as_the_group") {
-		if v, ok := d.GetOk("members_can_post_as_the_group"); ok {
-			log.Printf("[DEBUG] Updating members_can_post_as_the_group: %s", v.(string))
-			groupSetting.MembersCanPostAsTheGroup = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting MembersCanPostAsTheGroup")
-			groupSetting.MembersCanPostAsTheGroup = ""
-			nullFields = append(nullFields, "MembersCanPostAsTheGroup")
+	return d.SetNew("preset_values", values)
+}
+
+// flattenGroupSettings sets all attributes of the resource or data source
+// from the group settings.
+func flattenGroupSettings(d *schema.ResourceData, settings *groupSettings.Groups, attributes map[string]*schema.Schema) error {
+	fields := reflect.ValueOf(settings).Elem()
+	for attribute, field := range groupSettingsFields {
+		value := fields.FieldByName(field)
+
+		var err error
+		switch attributes[attribute].Type {
+		case schema.TypeBool:
+			err = d.Set(attribute, strings.EqualFold(value.String(), "true"))
+		case schema.TypeInt:
+			err = d.Set(attribute, int(value.Int()))
+		default:
+			err = d.Set(attribute, value.String())
 		}
-	}
-	if d.HasChange("message_moderation_level") {
-		if v, ok := d.GetOk("message_moderation_level"); ok {
-			log.Printf("[DEBUG] Updating message_moderation_level: %s", v.(string))
-			groupSetting.MessageModerationLevel = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting MessageModerationLevel")
-			groupSetting.MessageModerationLevel = ""
-			nullFields = append(nullFields, "MessageModerationLevel")
+		if err != nil {
+			return fmt.Errorf("Error setting %s in state: %s", attribute, err.Error())
 		}
 	}
-	if d.HasChange("PrimaryLanguage") {
-		if v, ok := d.GetOk("PrimaryLanguage"); ok {
-			log.Printf("[DEBUG] Updating PrimaryLanguage: %s", v.(string))
-			groupSetting.PrimaryLanguage = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting PrimaryLanguage")
-			groupSetting.PrimaryLanguage = ""
-			nullFields = append(nullFields, "PrimaryLanguage")
-		}
+	return nil
+}
+
+// expandGroupSettings returns the group settings with the given attributes
+// set from the configuration. Booleans are always sent, empty values are sent
+// as null to clear them.
+func expandGroupSettings(d *schema.ResourceData, attributes []string) *groupSettings.Groups {
+	settings := &groupSettings.Groups{
+		Email: strings.ToLower(d.Get("email").(string)),
 	}
-	if d.HasChange("reply_to") {
-		if v, ok := d.GetOk("reply_to"); ok {
-			log.Printf("[DEBUG] Updating reply_to: %s", v.(string))
-			groupSetting.ReplyTo = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting ReplyTo")
-			groupSetting.ReplyTo = ""
-			nullFields = append(nullFields, "ReplyTo")
+	fields := reflect.ValueOf(settings).Elem()
+
+	s := resourceGroupSettings().Schema
+	for _, attribute := range attributes {
+		field := groupSettingsFields[attribute]
+		value := d.Get(attribute)
+		log.Printf("[DEBUG] Setting %s: %v", attribute, value)
+
+		switch s[attribute].Type {
+		case schema.TypeBool:
+			fields.FieldByName(field).SetString(fmt.Sprint(value.(bool)))
+		case schema.TypeInt:
+			if value.(int) == 0 {
+				settings.NullFields = append(settings.NullFields, field)
+				continue
+			}
+			fields.FieldByName(field).SetInt(int64(value.(int)))
+		default:
+			if value.(string) == "" {
+				settings.NullFields = append(settings.NullFields, field)
+				continue
+			}
+			fields.FieldByName(field).SetString(value.(string))
 		}
 	}
-	if d.HasChange("send_message_deny_notification") {
-		if v, ok := d.GetOk("send_message_deny_notification"); ok {
-			log.Printf("[DEBUG] Updating send_message_deny_notification: %s", v.(string))
-			groupSetting.SendMessageDenyNotification = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting SendMessageDenyNotification")
-			groupSetting.SendMessageDenyNotification = ""
-			nullFields = append(nullFields, "SendMessageDenyNotification")
+
+	return settings
+}
+
+// groupSettingsWritable returns the attributes that can be set on a group.
+func groupSettingsWritable() []string {
+	writable := []string{}
+	for attribute, s := range resourceGroupSettings().Schema {
+		if _, ok := groupSettingsFields[attribute]; ok && s.Optional {
+			writable = append(writable, attribute)
 		}
 	}
-	if d.HasChange("spam_moderation_level") {
-		if v, ok := d.GetOk("spam_moderation_level"); ok {
-			log.Printf("[DEBUG] Updating spam_moderation_level: %s", v.(string))
-			groupSetting.SpamModerationLevel = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting SpamModerationLevel")
-			groupSetting.SpamModerationLevel = ""
-			nullFields = append(nullFields, "SpamModerationLevel")
+	return writable
+}
+
+func resourceGroupSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	// Only the configured attributes are patched, the others are left as
+	// they are and read back as computed values.
+	attributes := []string{}
+	for _, attribute := range groupSettingsWritable() {
+		if _, ok := d.GetOkExists(attribute); ok {
+			attributes = append(attributes, attribute)
 		}
 	}
-	if d.HasChange("who_can_approve_members") {
-		if v, ok := d.GetOk("who_can_approve_members"); ok {
-			log.Printf("[DEBUG] Updating who_can_approve_members: %s", v.(string))
-			groupSetting.WhoCanApproveMembers = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanApproveMembers")
-			groupSetting.WhoCanApproveMembers = ""
-			nullFields = append(nullFields, "WhoCanApproveMembers")
-		}
+
+	settings := expandGroupSettings(d, attributes)
+	if err := groupSettingsRememberOriginal(d, config, settings); err != nil {
+		return err
 	}
-	if d.HasChange("who_can_assist_content") {
-		if v, ok := d.GetOk("who_can_assist_content"); ok {
-			log.Printf("[DEBUG] Updating who_can_assist_content: %s", v.(string))
-			groupSetting.WhoCanAssistContent = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanAssistContent")
-			groupSetting.WhoCanAssistContent = ""
-			nullFields = append(nullFields, "WhoCanAssistContent")
-		}
+
+	if err := groupSettingsPatch(config, d.Get("email").(string), settings); err != nil {
+		return fmt.Errorf("[ERROR] Something went wrong while updating group settings for '%s': %s", d.Get("email").(string), err)
 	}
-	if d.HasChange("who_can_contact_owner") {
-		if v, ok := d.GetOk("who_can_contact_owner"); ok {
-			log.Printf("[DEBUG] Updating who_can_contact_owner: %s", v.(string))
-			groupSetting.WhoCanContactOwner = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanContactOwner")
-			groupSetting.WhoCanContactOwner = ""
-			nullFields = append(nullFields, "WhoCanContactOwner")
-		}
+
+	return resourceGroupSettingsRead(d, meta)
+}
+
+func resourceGroupSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	// A new preset also patches its values which already match the group,
+	// so their original value is remembered
+	preset := map[string]interface{}{}
+	if d.HasChange("preset") {
+		preset = groupSettingsPresets[d.Get("preset").(string)]
 	}
-	if d.HasChange("who_can_discover_group") {
-		if v, ok := d.GetOk("who_can_discover_group"); ok {
-			log.Printf("[DEBUG] Updating who_can_discover_group: %s", v.(string))
-			groupSetting.WhoCanDiscoverGroup = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanDiscoverGroup")
-			groupSetting.WhoCanDiscoverGroup = ""
-			nullFields = append(nullFields, "WhoCanDiscoverGroup")
+
+	attributes := []string{}
+	for _, attribute := range groupSettingsWritable() {
+		if _, ok := preset[attribute]; ok || d.HasChange(attribute) {
+			attributes = append(attributes, attribute)
 		}
 	}
-	if d.HasChange("who_can_join") {
-		if v, ok := d.GetOk("who_can_join"); ok {
-			log.Printf("[DEBUG] Updating who_can_join: %s", v.(string))
-			groupSetting.WhoCanJoin = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanJoin")
-			groupSetting.WhoCanJoin = ""
-			nullFields = append(nullFields, "WhoCanJoin")
-		}
+
+	// Only the computed preset attributes changed
+	if len(attributes) == 0 {
+		return resourceGroupSettingsRead(d, meta)
 	}
-	if d.HasChange("who_can_leave_group") {
-		if v, ok := d.GetOk("who_can_leave_group"); ok {
-			log.Printf("[DEBUG] Updating who_can_leave_group: %s", v.(string))
-			groupSetting.WhoCanLeaveGroup = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanLeaveGroup")
-			groupSetting.WhoCanLeaveGroup = ""
-			nullFields = append(nullFields, "WhoCanLeaveGroup")
-		}
+
+	settings := expandGroupSettings(d, attributes)
+	if err := groupSettingsRememberOriginal(d, config, settings); err != nil {
+		return err
 	}
-	if d.HasChange("who_can_moderate_content") {
-		if v, ok := d.GetOk("who_can_moderate_content"); ok {
-			log.Printf("[DEBUG] Updating who_can_moderate_content: %s", v.(string))
-			groupSetting.WhoCanModerateContent = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanModerateContent")
-			groupSetting.WhoCanModerateContent = ""
-			nullFields = append(nullFields, "WhoCanModerateContent")
-		}
+
+	if err := groupSettingsPatch(config, d.Get("email").(string), settings); err != nil {
+		return fmt.Errorf("[ERROR] Error updating group settings for '%s': %s", d.Get("email").(string), err)
 	}
-	if d.HasChange("who_can_moderate_members") {
-		if v, ok := d.GetOk("who_can_moderate_members"); ok {
-			log.Printf("[DEBUG] Updating who_can_moderate_members: %s", v.(string))
-			groupSetting.WhoCanModerateMembers = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanModerateMembers")
-			groupSetting.WhoCanModerateMembers = ""
-			nullFields = append(nullFields, "WhoCanModerateMembers")
-		}
+
+	return resourceGroupSettingsRead(d, meta)
+}
+
+func groupSettingsPatch(config *Config, email string, settings *groupSettings.Groups) error {
+	var err error
+	err = retry(func() error {
+		_, err = config.groupSettings.Groups.Patch(email, settings).Do()
+		return err
+	}, config.TimeoutMinutes)
+	return err
+}
+
+// groupSettingsPatched returns the attributes sent by a patch of the given
+// settings, set or nulled.
+func groupSettingsPatched(settings *groupSettings.Groups) []string {
+	nulled := map[string]bool{}
+	for _, field := range settings.NullFields {
+		nulled[field] = true
 	}
-	if d.HasChange("who_can_post_message") {
-		if v, ok := d.GetOk("who_can_post_message"); ok {
-			log.Printf("[DEBUG] Updating who_can_post_message: %s", v.(string))
-			groupSetting.WhoCanPostMessage = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanPostMessage")
-			groupSetting.WhoCanPostMessage = ""
-			nullFields = append(nullFields, "WhoCanPostMessage")
+
+	fields := reflect.ValueOf(settings).Elem()
+	attributes := []string{}
+	for attribute, field := range groupSettingsFields {
+		if nulled[field] || !fields.FieldByName(field).IsZero() {
+			attributes = append(attributes, attribute)
 		}
 	}
-	if d.HasChange("who_can_view_group") {
-		if v, ok := d.GetOk("who_can_view_group"); ok {
-			log.Printf("[DEBUG] Updating who_can_view_group: %s", v.(string))
-			groupSetting.WhoCanViewGroup = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanViewGroup")
-			groupSetting.WhoCanViewGroup = ""
-			nullFields = append(nullFields, "WhoCanViewGroup")
+	sort.Strings(attributes)
+	return attributes
+}
+
+// groupSettingsRememberOriginal stores the current value of the attributes
+// patched for the first time in `original_values`, so they can be restored
+// on delete.
+func groupSettingsRememberOriginal(d *schema.ResourceData, config *Config, settings *groupSettings.Groups) error {
+	original := d.Get("original_values").(map[string]interface{})
+
+	missing := []string{}
+	for _, attribute := range groupSettingsPatched(settings) {
+		if _, ok := original[attribute]; !ok {
+			missing = append(missing, attribute)
 		}
 	}
-	if d.HasChange("who_can_view_membership") {
-		if v, ok := d.GetOk("who_can_view_membership"); ok {
-			log.Printf("[DEBUG] Updating who_can_view_membership: %s", v.(string))
-			groupSetting.WhoCanViewMembership = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing groupSetting WhoCanViewMembership")
-			groupSetting.WhoCanViewMembership = ""
-			nullFields = append(nullFields, "WhoCanViewMembership")
-		}
+	if len(missing) == 0 {
+		return nil
 	}
 
 	var err error
-	err = retry(func() error {
-		_, err = config.groupSettings.Groups.Update(d.Get("email").(string), groupSetting).Do()
+	var current *groupSettings.Groups
+	err = retryInvalid(func() error {
+		current, err = config.groupSettings.Groups.Get(d.Get("email").(string)).Do()
 		return err
 	}, config.TimeoutMinutes)
-
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error updating group settings for '%s': %s", d.Get("email").(string), err)
+		return fmt.Errorf("[ERROR] Error fetching group settings. Make sure the group '%s' exists: %s ", d.Get("email").(string), err)
 	}
 
-	return resourceGroupSettingsRead(d, meta)
+	fields := reflect.ValueOf(current).Elem()
+	for _, attribute := range missing {
+		original[attribute] = fmt.Sprint(fields.FieldByName(groupSettingsFields[attribute]).Interface())
+	}
+
+	return d.Set("original_values", original)
 }
 
 func resourceGroupSettingsRead(d *schema.ResourceData, meta interface{}) error {
@@ -744,37 +846,42 @@ func resourceGroupSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(d.Get("email").(string))
-	d.Set("allow_external_members", groupSetting.AllowExternalMembers)
-	d.Set("allow_web_posting", groupSetting.AllowWebPosting)
-	d.Set("archive_only", groupSetting.ArchiveOnly)
-	d.Set("custom_footer_text", groupSetting.CustomFooterText)
-	d.Set("custom_reply_to", groupSetting.CustomReplyTo)
-	d.Set("description", groupSetting.Description)
-	d.Set("favorite_replies_on_top", groupSetting.FavoriteRepliesOnTop)
-	d.Set("include_custom_footer", groupSetting.IncludeCustomFooter)
-	d.Set("include_in_global_address_list", groupSetting.IncludeInGlobalAddressList)
-	d.Set("members_can_post_as_the_group", groupSetting.MembersCanPostAsTheGroup)
-	d.Set("message_moderation_level", groupSetting.MessageModerationLevel)
-	d.Set("primary_language", groupSetting.PrimaryLanguage)
-	d.Set("reply_to", groupSetting.ReplyTo)
-	d.Set("send_message_deny_notification", groupSetting.SendMessageDenyNotification)
-	d.Set("spam_moderation_level", groupSetting.SpamModerationLevel)
-	d.Set("who_can_approve_members", groupSetting.WhoCanApproveMembers)
-	d.Set("who_can_assist_content", groupSetting.WhoCanAssistContent)
-	d.Set("who_can_contact_owner", groupSetting.WhoCanContactOwner)
-	d.Set("who_can_discover_group", groupSetting.WhoCanDiscoverGroup)
-	d.Set("who_can_join", groupSetting.WhoCanJoin)
-	d.Set("who_can_leave_group", groupSetting.WhoCanLeaveGroup)
-	d.Set("who_can_moderate_content", groupSetting.WhoCanModerateContent)
-	d.Set("who_can_moderate_members", groupSetting.WhoCanModerateMembers)
-	d.Set("who_can_post_message", groupSetting.WhoCanPostMessage)
-	d.Set("who_can_view_group", groupSetting.WhoCanViewGroup)
-	d.Set("who_can_view_membership", groupSetting.WhoCanViewMembership)
-
-	return nil
+	return flattenGroupSettings(d, groupSetting, resourceGroupSettings().Schema)
 }
 
 func resourceGroupSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	// Restore the values the group had before they were managed
+	original := d.Get("original_values").(map[string]interface{})
+	if len(original) > 0 {
+		settings := &groupSettings.Groups{}
+		fields := reflect.ValueOf(settings).Elem()
+		for attribute, value := range original {
+			field := groupSettingsFields[attribute]
+			if value.(string) == "" || value.(string) == "0" {
+				settings.NullFields = append(settings.NullFields, field)
+				continue
+			}
+			if fields.FieldByName(field).Kind() == reflect.Int64 {
+				n, err := strconv.ParseInt(value.(string), 10, 64)
+				if err != nil {
+					return fmt.Errorf("[ERROR] Invalid original value of %s: %s", attribute, err)
+				}
+				fields.FieldByName(field).SetInt(n)
+				continue
+			}
+			fields.FieldByName(field).SetString(value.(string))
+		}
+
+		err := groupSettingsPatch(config, d.Get("email").(string), settings)
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Group %s is gone, nothing to restore", d.Get("email").(string))
+		} else if err != nil {
+			return fmt.Errorf("[ERROR] Error restoring group settings for '%s': %s", d.Get("email").(string), err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
@@ -789,33 +896,10 @@ func resourceGroupSettingsImporter(d *schema.ResourceData, meta interface{}) ([]
 	}
 
 	d.SetId(d.Id())
-	d.Set("allow_external_members", id.AllowExternalMembers)
-	d.Set("allow_web_posting", id.AllowWebPosting)
-	d.Set("archive_only", id.ArchiveOnly)
-	d.Set("custom_footer_text", id.CustomFooterText)
-	d.Set("custom_reply_to", id.CustomReplyTo)
-	d.Set("description", id.Description)
 	d.Set("email", id.Email)
-	d.Set("favorite_replies_on_top", id.FavoriteRepliesOnTop)
-	d.Set("include_custom_footer", id.IncludeCustomFooter)
-	d.Set("include_in_global_address_list", id.IncludeInGlobalAddressList)
-	d.Set("members_can_post_as_the_group", id.MembersCanPostAsTheGroup)
-	d.Set("message_moderation_level", id.MessageModerationLevel)
-	d.Set("primary_language", id.PrimaryLanguage)
-	d.Set("reply_to", id.ReplyTo)
-	d.Set("send_message_deny_notification", id.SendMessageDenyNotification)
-	d.Set("spam_moderation_level", id.SpamModerationLevel)
-	d.Set("who_can_approve_members", id.WhoCanApproveMembers)
-	d.Set("who_can_assist_content", id.WhoCanAssistContent)
-	d.Set("who_can_contact_owner", id.WhoCanContactOwner)
-	d.Set("who_can_discover_group", id.WhoCanDiscoverGroup)
-	d.Set("who_can_join", id.WhoCanJoin)
-	d.Set("who_can_leave_group", id.WhoCanLeaveGroup)
-	d.Set("who_can_moderate_content", id.WhoCanModerateContent)
-	d.Set("who_can_moderate_members", id.WhoCanModerateMembers)
-	d.Set("who_can_post_message", id.WhoCanPostMessage)
-	d.Set("who_can_view_group", id.WhoCanViewGroup)
-	d.Set("who_can_view_membership", id.WhoCanViewMembership)
+	if err := flattenGroupSettings(d, id, resourceGroupSettings().Schema); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,264 @@
+package gsuite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	groupSettings "google.golang.org/api/groupssettings/v1"
+)
+
+func TestGroupSettingsFields(t *testing.T) {
+	s := resourceGroupSettings().Schema
+	for attribute := range groupSettingsFields {
+		if _, ok := s[attribute]; !ok {
+			t.Errorf("%s is not in the schema", attribute)
+		}
+	}
+	unmapped := map[string]bool{
+		"email":            true,
+		"original_values":  true,
+		"preset":           true,
+		"preset_overrides": true,
+		"preset_values":    true,
+	}
+	for attribute := range s {
+		if _, ok := groupSettingsFields[attribute]; !ok && !unmapped[attribute] {
+			t.Errorf("%s is not mapped on a field", attribute)
+		}
+	}
+
+	for name, preset := range groupSettingsPresets {
+		for attribute, value := range preset {
+			if _, ok := groupSettingsFields[attribute]; !ok || !s[attribute].Optional {
+				t.Errorf("preset %s: %s can not be set", name, attribute)
+				continue
+			}
+			if _, ok := value.(bool); ok != (s[attribute].Type == schema.TypeBool) {
+				t.Errorf("preset %s: %s has the wrong type", name, attribute)
+			}
+			if s[attribute].ValidateFunc != nil {
+				if _, errs := s[attribute].ValidateFunc(value, attribute); len(errs) > 0 {
+					t.Errorf("preset %s: %s", name, errs[0])
+				}
+			}
+		}
+	}
+}
+
+func TestFlattenExpandGroupSettings(t *testing.T) {
+	settings := &groupSettings.Groups{
+		AllowExternalMembers: "true",
+		AllowWebPosting:      "false",
+		MaxMessageBytes:      26214400,
+		WhoCanAdd:            "ALL_MANAGERS_CAN_ADD",
+	}
+
+	s := resourceGroupSettings().Schema
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{"email": "group@domain.ext"})
+	if err := flattenGroupSettings(d, settings, s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !d.Get("allow_external_members").(bool) || d.Get("allow_web_posting").(bool) {
+		t.Errorf("booleans were not converted: %v, %v", d.Get("allow_external_members"), d.Get("allow_web_posting"))
+	}
+	if d.Get("max_message_bytes").(int) != 26214400 {
+		t.Errorf("expected max_message_bytes 26214400, got %v", d.Get("max_message_bytes"))
+	}
+
+	expanded := expandGroupSettings(d, []string{"allow_external_members", "allow_web_posting", "max_message_bytes", "who_can_add", "custom_footer_text"})
+	if expanded.AllowExternalMembers != "true" || expanded.AllowWebPosting != "false" {
+		t.Errorf("booleans were not converted: %q, %q", expanded.AllowExternalMembers, expanded.AllowWebPosting)
+	}
+	if expanded.MaxMessageBytes != 26214400 || expanded.WhoCanAdd != "ALL_MANAGERS_CAN_ADD" {
+		t.Errorf("unexpected values: %d, %q", expanded.MaxMessageBytes, expanded.WhoCanAdd)
+	}
+	if len(expanded.NullFields) != 1 || expanded.NullFields[0] != "CustomFooterText" {
+		t.Errorf("expected CustomFooterText to be nulled, got %v", expanded.NullFields)
+	}
+}
+
+func TestResourceGroupSettingsMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "group@domain.ext",
+		Attributes: map[string]string{
+			"allow_external_members": "TRUE",
+			"archive_only":           "",
+			"who_can_join":           "CAN_REQUEST_TO_JOIN",
+		},
+	}
+
+	is, err := resourceGroupSettingsMigrateState(0, is, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"allow_external_members": "true",
+		"archive_only":           "false",
+		"who_can_join":           "CAN_REQUEST_TO_JOIN",
+	}
+	for k, v := range expected {
+		if is.Attributes[k] != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, is.Attributes[k])
+		}
+	}
+}
+
+func TestResourceGroupSettingsPresetDiff(t *testing.T) {
+	r := resourceGroupSettings()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":        "group@domain.ext",
+		"preset":       "team_discussion",
+		"who_can_join": "INVITED_CAN_JOIN",
+	})
+
+	diff, err := r.Diff(nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"who_can_join":               "INVITED_CAN_JOIN",
+		"who_can_post_message":       "ALL_MEMBERS_CAN_POST",
+		"allow_external_members":     "false",
+		"preset_overrides.#":         "1",
+		"preset_values.who_can_join": "",
+		"preset_values.reply_to":     "REPLY_TO_LIST",
+	}
+	for k, v := range expected {
+		attr, ok := diff.Attributes[k]
+		if v == "" {
+			if ok && attr.New != "" {
+				t.Errorf("expected no %s, got %q", k, attr.New)
+			}
+			continue
+		}
+		if !ok || attr.New != v {
+			t.Errorf("expected %s to be %q, got %#v", k, v, attr)
+		}
+	}
+
+	// The live group drifted from the preset, the override is unchanged
+	state := &terraform.InstanceState{
+		ID: "group@domain.ext",
+		Attributes: map[string]string{
+			"email":                  "group@domain.ext",
+			"preset":                 "team_discussion",
+			"who_can_join":           "INVITED_CAN_JOIN",
+			"who_can_post_message":   "ANYONE_CAN_POST",
+			"preset_overrides.#":     "1",
+			"preset_overrides.12345": "who_can_join",
+		},
+	}
+	diff, err = r.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attr, ok := diff.Attributes["who_can_post_message"]; !ok || attr.New != "ALL_MEMBERS_CAN_POST" {
+		t.Errorf("expected who_can_post_message to be reset to the preset, got %#v", attr)
+	}
+	if attr, ok := diff.Attributes["who_can_join"]; ok && attr.New != attr.Old {
+		t.Errorf("expected the override of who_can_join to be kept, got %#v", attr)
+	}
+}
+
+func TestResourceGroupSettingsPresetDiffAdopt(t *testing.T) {
+	r := resourceGroupSettings()
+
+	// An existing group, a configured attribute which is changed is an
+	// override, the unconfigured ones are set from the preset
+	state := &terraform.InstanceState{
+		ID: "group@domain.ext",
+		Attributes: map[string]string{
+			"email":                  "group@domain.ext",
+			"allow_external_members": "false",
+			"who_can_join":           "ALL_IN_DOMAIN_CAN_JOIN",
+			"who_can_post_message":   "ANYONE_CAN_POST",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":        "group@domain.ext",
+		"preset":       "team_discussion",
+		"who_can_join": "INVITED_CAN_JOIN",
+	})
+
+	diff, err := r.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if attr, ok := diff.Attributes["who_can_join"]; !ok || attr.New != "INVITED_CAN_JOIN" {
+		t.Errorf("expected the configured who_can_join to be kept, got %#v", attr)
+	}
+	if attr, ok := diff.Attributes["who_can_post_message"]; !ok || attr.New != "ALL_MEMBERS_CAN_POST" {
+		t.Errorf("expected who_can_post_message to be set from the preset, got %#v", attr)
+	}
+	if attr, ok := diff.Attributes["preset_overrides.#"]; !ok || attr.New != "1" {
+		t.Errorf("expected who_can_join to be an override, got %#v", attr)
+	}
+
+	expected := map[string]string{
+		"preset_values.who_can_post_message":   "ALL_MEMBERS_CAN_POST",
+		"preset_values.reply_to":               "REPLY_TO_LIST",
+		"preset_values.who_can_join":           "",
+		"preset_values.allow_external_members": "",
+	}
+	for k, v := range expected {
+		attr, ok := diff.Attributes[k]
+		if v == "" {
+			if ok && attr.New != "" {
+				t.Errorf("expected no %s, got %q", k, attr.New)
+			}
+			continue
+		}
+		if !ok || attr.New != v {
+			t.Errorf("expected %s to be %q, got %#v", k, v, attr)
+		}
+	}
+
+	// An override set to the value of the preset
+	state.Attributes["preset"] = "team_discussion"
+	state.Attributes["who_can_join"] = "INVITED_CAN_JOIN"
+	state.Attributes["preset_overrides.#"] = "1"
+	state.Attributes["preset_overrides.12345"] = "who_can_join"
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":        "group@domain.ext",
+		"preset":       "team_discussion",
+		"who_can_join": "ALL_IN_DOMAIN_CAN_JOIN",
+	})
+
+	diff, err = r.Diff(state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attr, ok := diff.Attributes["who_can_join"]; !ok || attr.New != "ALL_IN_DOMAIN_CAN_JOIN" {
+		t.Errorf("expected who_can_join to be set to the preset value, got %#v", attr)
+	}
+	if attr, ok := diff.Attributes["preset_values.who_can_join"]; ok && attr.New != "" {
+		t.Errorf("expected the configured who_can_join not to be a preset value, got %#v", attr)
+	}
+}
+
+func TestGroupSettingsPatched(t *testing.T) {
+	r := resourceGroupSettings()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"email":                  "group@domain.ext",
+		"allow_external_members": false,
+		"who_can_join":           "INVITED_CAN_JOIN",
+		"custom_footer_text":     "",
+	})
+
+	// Booleans are always sent, empty values are nulled
+	settings := expandGroupSettings(d, []string{"allow_external_members", "custom_footer_text", "max_message_bytes", "who_can_join"})
+	expected := []string{"allow_external_members", "custom_footer_text", "max_message_bytes", "who_can_join"}
+	if patched := groupSettingsPatched(settings); !reflect.DeepEqual(patched, expected) {
+		t.Errorf("expected %v, got %v", expected, patched)
+	}
+
+	if patched := groupSettingsPatched(expandGroupSettings(d, nil)); len(patched) != 0 {
+		t.Errorf("expected nothing to be patched, got %v", patched)
+	}
+}
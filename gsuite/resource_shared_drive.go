@@ -0,0 +1,302 @@
+package gsuite
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceSharedDrive() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSharedDriveCreate,
+		Read:   resourceSharedDriveRead,
+		Update: resourceSharedDriveUpdate,
+		Delete: resourceSharedDriveDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Without the block all restrictions are lifted
+			"restrictions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// Only administrators can change the restrictions
+						"admin_managed_restrictions": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"copy_requires_writer_permission": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"domain_users_only": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"drive_members_only": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			// Hidden from the default view of impersonated_user_email
+			"hidden": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"created_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandSharedDriveRestrictions(d *schema.ResourceData) *drive.DriveRestrictions {
+	restrictions := &drive.DriveRestrictions{
+		ForceSendFields: []string{"AdminManagedRestrictions", "CopyRequiresWriterPermission", "DomainUsersOnly", "DriveMembersOnly"},
+	}
+	if raw := d.Get("restrictions").([]interface{}); len(raw) > 0 && raw[0] != nil {
+		r := raw[0].(map[string]interface{})
+		restrictions.AdminManagedRestrictions = r["admin_managed_restrictions"].(bool)
+		restrictions.CopyRequiresWriterPermission = r["copy_requires_writer_permission"].(bool)
+		restrictions.DomainUsersOnly = r["domain_users_only"].(bool)
+		restrictions.DriveMembersOnly = r["drive_members_only"].(bool)
+	}
+	return restrictions
+}
+
+func flattenSharedDriveRestrictions(restrictions *drive.DriveRestrictions) []map[string]interface{} {
+	if restrictions == nil {
+		return nil
+	}
+	return []map[string]interface{}{
+		{
+			"admin_managed_restrictions":      restrictions.AdminManagedRestrictions,
+			"copy_requires_writer_permission": restrictions.CopyRequiresWriterPermission,
+			"domain_users_only":               restrictions.DomainUsersOnly,
+			"drive_members_only":              restrictions.DriveMembersOnly,
+		},
+	}
+}
+
+// sharedDriveRestricted tells whether any restriction is set on a drive.
+func sharedDriveRestricted(restrictions *drive.DriveRestrictions) bool {
+	return restrictions != nil && (restrictions.AdminManagedRestrictions ||
+		restrictions.CopyRequiresWriterPermission ||
+		restrictions.DomainUsersOnly ||
+		restrictions.DriveMembersOnly)
+}
+
+// sharedDriveCreatedSince returns the most recent of the shared drives with
+// the given name created since the given time, or nil.
+func sharedDriveCreatedSince(drives []*drive.Drive, name string, since time.Time) *drive.Drive {
+	var found *drive.Drive
+	var foundTime time.Time
+	for _, d := range drives {
+		created, err := time.Parse(time.RFC3339, d.CreatedTime)
+		if d.Name != name || err != nil || created.Before(since) {
+			continue
+		}
+		if found == nil || created.After(foundTime) {
+			found, foundTime = d, created
+		}
+	}
+	return found
+}
+
+// sharedDriveFindCreated looks up the shared drive created by a request whose
+// response was lost. The API only tells the request id was used, the drive is
+// the most recent one with the name among the drives of the acting user.
+func sharedDriveFindCreated(config *Config, driveSvc *drive.Service, name string, since time.Time) (*drive.Drive, error) {
+	query := fmt.Sprintf("name = '%s'", strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(name))
+
+	var drives []*drive.Drive
+	err := retry(func() error {
+		drives = nil
+		return driveSvc.Drives.List().Q(query).Fields("nextPageToken", "drives(id, name, createdTime)").
+			Pages(context.Background(), func(list *drive.DriveList) error {
+				drives = append(drives, list.Drives...)
+				return nil
+			})
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	found := sharedDriveCreatedSince(drives, name, since)
+	if found == nil {
+		return nil, fmt.Errorf("the request was already handled, but no shared drive named %q was created since %s", name, since.Format(time.RFC3339))
+	}
+	return found, nil
+}
+
+func sharedDriveSetHidden(config *Config, driveSvc *drive.Service, id string, hidden bool) error {
+	return retry(func() error {
+		var err error
+		if hidden {
+			_, err = driveSvc.Drives.Hide(id).Do()
+		} else {
+			_, err = driveSvc.Drives.Unhide(id).Do()
+		}
+		return err
+	}, config.TimeoutMinutes)
+}
+
+func resourceSharedDriveCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	sharedDrive := &drive.Drive{
+		Name: d.Get("name").(string),
+	}
+
+	// The same request id makes retries idempotent, a conflict means the
+	// drive was created by an earlier attempt whose response was lost
+	requestID := resource.UniqueId()
+	// Allow for clock skew with the API
+	started := time.Now().Add(-time.Minute)
+	var created *drive.Drive
+	err = retryPassDuplicate(func() error {
+		created, err = driveSvc.Drives.Create(requestID, sharedDrive).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 409 {
+		log.Printf("[INFO] Shared drive %s was already created, looking it up", sharedDrive.Name)
+		created, err = sharedDriveFindCreated(config, driveSvc, sharedDrive.Name, started)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating shared drive: %s", err)
+	}
+
+	d.SetId(created.Id)
+	log.Printf("[INFO] Created shared drive %s (%s)", created.Name, created.Id)
+
+	// Restrictions can't be set when creating the drive, retrying for 404's
+	// until the drive exists
+	err = retryNotFound(func() error {
+		_, err = driveSvc.Drives.Update(d.Id(), &drive.Drive{Restrictions: expandSharedDriveRestrictions(d)}).
+			UseDomainAdminAccess(true).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error setting restrictions of shared drive: %s", err)
+	}
+
+	if d.Get("hidden").(bool) {
+		if err = sharedDriveSetHidden(config, driveSvc, d.Id(), true); err != nil {
+			return fmt.Errorf("[ERROR] Error hiding shared drive: %s", err)
+		}
+	}
+
+	return resourceSharedDriveRead(d, meta)
+}
+
+func resourceSharedDriveUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("name") || d.HasChange("restrictions") {
+		sharedDrive := &drive.Drive{
+			Name:         d.Get("name").(string),
+			Restrictions: expandSharedDriveRestrictions(d),
+		}
+
+		log.Printf("[DEBUG] Updating shared drive %s", d.Id())
+		err = retry(func() error {
+			_, err = driveSvc.Drives.Update(d.Id(), sharedDrive).UseDomainAdminAccess(true).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error updating shared drive: %s", err)
+		}
+	}
+
+	if d.HasChange("hidden") {
+		if err = sharedDriveSetHidden(config, driveSvc, d.Id(), d.Get("hidden").(bool)); err != nil {
+			return fmt.Errorf("[ERROR] Error updating the visibility of shared drive: %s", err)
+		}
+	}
+
+	return resourceSharedDriveRead(d, meta)
+}
+
+func resourceSharedDriveRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	var sharedDrive *drive.Drive
+	err = retry(func() error {
+		sharedDrive, err = driveSvc.Drives.Get(d.Id()).UseDomainAdminAccess(true).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return handleNotFoundError(err, d, fmt.Sprintf("Shared drive %q", d.Id()))
+	}
+
+	d.Set("name", sharedDrive.Name)
+	d.Set("hidden", sharedDrive.Hidden)
+	d.Set("created_time", sharedDrive.CreatedTime)
+	// An unrestricted drive matches a configuration without the block
+	restrictions := flattenSharedDriveRestrictions(sharedDrive.Restrictions)
+	if len(d.Get("restrictions").([]interface{})) == 0 && !sharedDriveRestricted(sharedDrive.Restrictions) {
+		restrictions = nil
+	}
+	if err := d.Set("restrictions", restrictions); err != nil {
+		return fmt.Errorf("Error setting restrictions in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Only empty shared drives can be deleted, by one of their organizers
+func resourceSharedDriveDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	driveSvc, err := config.driveService(config.ImpersonatedUserEmail)
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		return driveSvc.Drives.Delete(d.Id()).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting shared drive, make sure it is empty and %s is an organizer: %s", config.ImpersonatedUserEmail, err)
+	}
+
+	d.SetId("")
+	return nil
+}
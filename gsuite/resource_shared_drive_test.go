@@ -0,0 +1,49 @@
+package gsuite
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestFlattenSharedDriveRestrictions(t *testing.T) {
+	if r := flattenSharedDriveRestrictions(nil); r != nil {
+		t.Errorf("expected no restrictions, got %v", r)
+	}
+	// An empty block in the configuration matches restrictions all set to false
+	r := flattenSharedDriveRestrictions(&drive.DriveRestrictions{})
+	if len(r) != 1 || r[0]["domain_users_only"] != false || r[0]["admin_managed_restrictions"] != false {
+		t.Errorf("expected restrictions set to false, got %v", r)
+	}
+	r = flattenSharedDriveRestrictions(&drive.DriveRestrictions{DomainUsersOnly: true})
+	if len(r) != 1 || r[0]["domain_users_only"] != true || r[0]["drive_members_only"] != false {
+		t.Errorf("expected domain_users_only restriction, got %v", r)
+	}
+}
+
+func TestSharedDriveRestricted(t *testing.T) {
+	if sharedDriveRestricted(nil) || sharedDriveRestricted(&drive.DriveRestrictions{}) {
+		t.Errorf("expected no restrictions")
+	}
+	if !sharedDriveRestricted(&drive.DriveRestrictions{CopyRequiresWriterPermission: true}) {
+		t.Errorf("expected copy_requires_writer_permission to be a restriction")
+	}
+}
+
+func TestSharedDriveCreatedSince(t *testing.T) {
+	since, _ := time.Parse(time.RFC3339, "2021-04-01T10:00:00Z")
+	drives := []*drive.Drive{
+		{Id: "old", Name: "Team", CreatedTime: "2021-03-01T10:00:00.000Z"},
+		{Id: "other", Name: "Other", CreatedTime: "2021-04-01T10:05:00.000Z"},
+		{Id: "created", Name: "Team", CreatedTime: "2021-04-01T10:01:00.000Z"},
+		{Id: "earlier", Name: "Team", CreatedTime: "2021-04-01T10:00:30.000Z"},
+	}
+
+	if found := sharedDriveCreatedSince(drives, "Team", since); found == nil || found.Id != "created" {
+		t.Errorf("expected the most recent drive named Team, got %v", found)
+	}
+	if found := sharedDriveCreatedSince(drives, "Missing", since); found != nil {
+		t.Errorf("expected no drive, got %v", found)
+	}
+}
@@ -1,13 +1,21 @@
 package gsuite
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/pkg/errors"
 	"github.com/sethvargo/go-password/password"
 	directory "google.golang.org/api/admin/directory/v1"
@@ -119,6 +127,217 @@ func orderValues(j string) (error, string) {
 	return err, string(s)
 }
 
+// hashFunctions maps the accepted (case insensitive) hash_function values to
+// the names the Directory API expects.
+var hashFunctions = map[string]string{
+	"md5":   "MD5",
+	"sha-1": "SHA-1",
+	"crypt": "crypt",
+}
+
+var passwordHashFormats = map[string]*regexp.Regexp{
+	"MD5":   regexp.MustCompile(`^[0-9a-fA-F]{32}$`),
+	"SHA-1": regexp.MustCompile(`^[0-9a-fA-F]{40}$`),
+	// DES, MD5 ($1$), SHA-256 ($5$) and SHA-512 ($6$) crypt
+	"crypt": regexp.MustCompile(`^([./0-9A-Za-z]{13}|\$[156]\$(rounds=[0-9]+\$)?[./0-9A-Za-z]{1,16}\$[./0-9A-Za-z]+)$`),
+}
+
+const passwordFingerprintPrefix = "sha256$"
+
+// validatePasswordHash checks that a pre-hashed password matches the format of
+// its hash function.
+func validatePasswordHash(hashFunction, password string) error {
+	format, ok := passwordHashFormats[hashFunction]
+	if !ok {
+		return fmt.Errorf("unsupported hash_function %q", hashFunction)
+	}
+	if !format.MatchString(password) {
+		return fmt.Errorf("password is not a valid %s hash", hashFunction)
+	}
+	return nil
+}
+
+// passwordFingerprint returns a salted sha256 of the password, this is what
+// is kept in the state instead of the password itself.
+func passwordFingerprint(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return passwordFingerprintWithSalt(password, hex.EncodeToString(salt)), nil
+}
+
+func passwordFingerprintWithSalt(password, salt string) string {
+	sum := sha256.Sum256([]byte(salt + password))
+	return passwordFingerprintPrefix + salt + "$" + hex.EncodeToString(sum[:])
+}
+
+func isPasswordFingerprint(value string) bool {
+	return strings.HasPrefix(value, passwordFingerprintPrefix) && strings.Count(value, "$") == 2
+}
+
+// passwordMatchesFingerprint reports whether password is the value the
+// fingerprint was computed from.
+func passwordMatchesFingerprint(password, fingerprint string) bool {
+	if !isPasswordFingerprint(fingerprint) {
+		return false
+	}
+	salt := strings.Split(fingerprint, "$")[1]
+	expected := passwordFingerprintWithSalt(password, salt)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(fingerprint)) == 1
+}
+
+func suppressPasswordDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+
+	// The password has to be sent again when it is rotated or hashed
+	// differently, so it can't be suppressed in those cases.
+	if d.HasChange("password_version") {
+		return false
+	}
+	if o, n := d.GetChange("hash_function"); !strings.EqualFold(o.(string), n.(string)) {
+		return false
+	}
+
+	return passwordMatchesFingerprint(new, old)
+}
+
+// resourceUserCustomizeDiff validates the password against the configured
+// hash_function, this can only be done when both values are known.
+func resourceUserCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("password") || !d.NewValueKnown("hash_function") {
+		return nil
+	}
+
+	pw := d.Get("password").(string)
+	hashFunction := d.Get("hash_function").(string)
+
+	if pw == "" {
+		// Rotating without a password generates a new one
+		if d.Id() != "" && d.HasChange("password_version") {
+			if d.Get("pgp_key").(string) != "" {
+				if err := d.SetNewComputed("encrypted_generated_password"); err != nil {
+					return err
+				}
+			} else if d.Get("expose_generated_password").(bool) {
+				if err := d.SetNewComputed("generated_password"); err != nil {
+					return err
+				}
+			}
+		}
+		if hashFunction != "" {
+			return fmt.Errorf("hash_function is set but no password was provided")
+		}
+		return nil
+	}
+
+	if hashFunction != "" && !isPasswordFingerprint(pw) {
+		if err := validatePasswordHash(hashFunctions[strings.ToLower(hashFunction)], pw); err != nil {
+			return fmt.Errorf("invalid password: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// resourceUserMigrateState replaces plain text passwords in the state by
+// their fingerprint, and sets the default of change_password_next_login,
+// which is not read back.
+func resourceUserMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	switch v {
+	case 0:
+		log.Println("[INFO] Found G Suite User State v0; migrating to v1")
+		if pw := is.Attributes["password"]; pw != "" && !isPasswordFingerprint(pw) {
+			fingerprint, err := passwordFingerprint(pw)
+			if err != nil {
+				return is, err
+			}
+			is.Attributes["password"] = fingerprint
+		}
+		if _, ok := is.Attributes["change_password_next_login"]; !ok {
+			is.Attributes["change_password_next_login"] = "true"
+		}
+		return is, nil
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// userPassword sets the password and hash function from the config on the
+// user, and returns the fingerprint of the password to store in the state
+// once it was sent.
+func userPassword(d *schema.ResourceData, user *directory.User) (string, error) {
+	// The state only ever holds a fingerprint, which can't be sent
+	pw := d.Get("password").(string)
+	if pw == "" || isPasswordFingerprint(pw) {
+		return "", nil
+	}
+
+	user.Password = pw
+	if v, ok := d.GetOk("hash_function"); ok {
+		user.HashFunction = hashFunctions[strings.ToLower(v.(string))]
+		log.Printf("[DEBUG] Setting %s: %s", "hash_function", user.HashFunction)
+	}
+
+	return passwordFingerprint(pw)
+}
+
+// userRestoreUnsentPassword puts the password of the state back when the
+// planned password was not sent, so a failed apply never saves it in plain
+// text.
+func userRestoreUnsentPassword(d *schema.ResourceData) {
+	if pw := d.Get("password").(string); pw != "" && !isPasswordFingerprint(pw) {
+		old, _ := d.GetChange("password")
+		d.Set("password", old)
+	}
+}
+
+// userGeneratePassword generates a random password according to the
+// password_generator settings.
+func userGeneratePassword(d *schema.ResourceData) (string, error) {
+	length, digits, symbols := 32, 4, 4
+	if v, ok := d.GetOk("password_generator"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		generator := v.([]interface{})[0].(map[string]interface{})
+		length = generator["length"].(int)
+		digits = generator["digits"].(int)
+		symbols = generator["symbols"].(int)
+	}
+
+	if digits+symbols > length {
+		return "", fmt.Errorf("password_generator: digits and symbols exceed the length of %d", length)
+	}
+
+	return password.Generate(length, digits, symbols, false, length > 32)
+}
+
+// userSetGeneratedPassword exposes a generated password in the state, either
+// encrypted to pgp_key or in plain text when expose_generated_password is set.
+func userSetGeneratedPassword(d *schema.ResourceData, generated string) error {
+	if key := d.Get("pgp_key").(string); key != "" {
+		encrypted, fingerprint, err := encryptWithPGPKey(generated, key)
+		if err != nil {
+			return err
+		}
+		d.Set("encrypted_generated_password", encrypted)
+		d.Set("key_fingerprint", fingerprint)
+		d.Set("generated_password", "")
+		return nil
+	}
+
+	if d.Get("expose_generated_password").(bool) {
+		d.Set("generated_password", generated)
+	}
+
+	return nil
+}
+
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceUserCreate,
@@ -129,6 +348,14 @@ func resourceUser() *schema.Resource {
 			State: resourceUserImporter,
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceUserCustomizeDiff,
+			validateCustomSchemasDiff,
+		),
+
+		SchemaVersion: 1,
+		MigrateState:  resourceUserMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"aliases": {
 				Type:     schema.TypeSet,
@@ -220,15 +447,98 @@ func resourceUser() *schema.Resource {
 				},
 			},
 
+			// Only a salted fingerprint of the password is kept in the state
 			"password": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressPasswordDiff,
 			},
 
 			// md5, sha-1 and crypt
 			"hash_function": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: validation.StringInSlice(
+					[]string{"MD5", "SHA-1", "crypt"},
+					true,
+				),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+
+			// Changing password_version sends the password again, even when
+			// the password itself did not change.
+			"password_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Only applied when the user is created or a generated password
+			// is rotated, it is not read back as it resets once the user
+			// changed their password
+			"change_password_next_login": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// Settings for the password generated when no password is set
+			"password_generator": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"length": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      32,
+							ValidateFunc: validation.IntBetween(8, 100),
+						},
+						"digits": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      4,
+							ValidateFunc: validation.IntBetween(0, 10),
+						},
+						"symbols": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      4,
+							ValidateFunc: validation.IntBetween(0, 20),
+						},
+					},
+				},
+			},
+
+			"expose_generated_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// base64 encoded or ASCII armored public key
+			"pgp_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"generated_password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"encrypted_generated_password": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"key_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 
 			"posix_accounts": {
@@ -283,6 +593,23 @@ func resourceUser() *schema.Resource {
 				StateFunc: func(val interface{}) string {
 					return strings.ToLower(val.(string))
 				},
+				ValidateFunc: validateEmail,
+			},
+
+			// Changing primary_email renames the user, G Suite keeps the old
+			// address as an alias unless told otherwise.
+			"keep_old_email_as_alias": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// Former primary addresses that were kept as an alias after a rename,
+			// these are managed by the provider and not part of `aliases`.
+			"renamed_aliases": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
 			"recovery_email": {
@@ -339,6 +666,25 @@ func resourceUser() *schema.Resource {
 				Optional: true,
 			},
 
+			// Requires the admin.directory.user.security scope
+			"revoke_tokens_on_suspend": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"sign_out_on_suspend": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"change_password_on_suspend": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"custom_schema": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -588,7 +934,10 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	user.Name = userName
 
-	var err error
+	fingerprint, err := userPassword(d, user)
+	if err != nil {
+		return err
+	}
 
 	updateExisting := config.UpdateExisting
 	if v, ok := d.GetOk("update_existing"); ok {
@@ -616,6 +965,10 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 		if locatedUser != nil {
 			log.Printf("[INFO] found existing user %s", locatedUser.PrimaryEmail)
 
+			// Passwords of existing users are left alone
+			user.Password = ""
+			user.HashFunction = ""
+
 			err = retry(func() error {
 				_, err = config.directory.Users.Update(locatedUser.Id, user).Do()
 				return err
@@ -624,6 +977,9 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 			if err != nil {
 				return fmt.Errorf("[ERROR] Error updating existing user: %s", err)
 			}
+			if fingerprint != "" {
+				d.Set("password", fingerprint)
+			}
 
 			err = userAliasesUpdate(config, locatedUser, aliases)
 
@@ -633,31 +989,29 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 
 			log.Printf("[INFO] Updated user: %s", user.PrimaryEmail)
 			d.SetId(locatedUser.Id)
+
+			if userRevokeAccessOnSuspend(d) {
+				if err = userRevokeAccess(config, locatedUser.Id); err != nil {
+					return err
+				}
+			}
+
 			return resourceUserRead(d, meta)
 		}
 	}
 
-	// Transmit password related state on account creation only.
-	if v, ok := d.GetOk("password"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "password", v.(string))
-		user.Password = v.(string)
-	}
-
+	generatedPassword := ""
 	if user.Password == "" {
-		res, err := password.Generate(32, 4, 4, false, false)
+		generatedPassword, err = userGeneratePassword(d)
 		if err != nil {
 			return err
 		}
-		user.Password = res
+		user.Password = generatedPassword
 		log.Printf("[INFO] A random password was generated for the user")
 	}
 
-	if v, ok := d.GetOk("hash_function"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "hash_function", v.(string))
-		user.HashFunction = v.(string)
-	}
-
-	user.ChangePasswordAtNextLogin = true
+	user.ChangePasswordAtNextLogin = d.Get("change_password_next_login").(bool)
+	user.ForceSendFields = append(user.ForceSendFields, "ChangePasswordAtNextLogin")
 
 	var createdUser *directory.User
 	err = retry(func() error {
@@ -668,6 +1022,9 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error creating user: %s", err)
 	}
+	if fingerprint != "" {
+		d.Set("password", fingerprint)
+	}
 
 	// Try to read the user, retrying for 404's
 	err = retryNotFound(func() error {
@@ -700,6 +1057,19 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(createdUser.Id)
 	log.Printf("[INFO] Created user: %s", createdUser.PrimaryEmail)
+
+	if userRevokeAccessOnSuspend(d) {
+		if err = userRevokeAccess(config, createdUser.Id); err != nil {
+			return err
+		}
+	}
+
+	if generatedPassword != "" {
+		if err = userSetGeneratedPassword(d, generatedPassword); err != nil {
+			return err
+		}
+	}
+
 	return resourceUserRead(d, meta)
 }
 
@@ -804,10 +1174,48 @@ func userPosixCreate(d *schema.ResourceData, userID string, meta interface{}) er
 }
 
 func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := userUpdate(d, meta); err != nil {
+		userRestoreUnsentPassword(d)
+		return err
+	}
+	return nil
+}
+
+func userUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	if d.HasChange("primary_email") {
+		if err := userRename(d, config); err != nil {
+			return err
+		}
+	}
+
 	user := &directory.User{}
-	nullFields := []string{"change_password_next_login", "hash_function", "password"}
+	nullFields := []string{}
+
+	// Passwords are only sent when they, or password_version, changed
+	generatedPassword, fingerprint := "", ""
+	if d.HasChange("password") || d.HasChange("password_version") || d.HasChange("hash_function") {
+		var err error
+		if fingerprint, err = userPassword(d, user); err != nil {
+			return err
+		}
+
+		if user.Password == "" && d.Get("password").(string) == "" && d.HasChange("password_version") {
+			var err error
+			generatedPassword, err = userGeneratePassword(d)
+			if err != nil {
+				return err
+			}
+			user.Password = generatedPassword
+			user.ChangePasswordAtNextLogin = d.Get("change_password_next_login").(bool)
+			user.ForceSendFields = append(user.ForceSendFields, "ChangePasswordAtNextLogin")
+			log.Printf("[INFO] A random password was generated for the user")
+		}
+	}
+	if user.Password == "" {
+		nullFields = append(nullFields, "hash_function", "password")
+	}
 
 	if d.HasChange("deletion_time") {
 		if v, ok := d.GetOk("deletion_time"); ok {
@@ -819,16 +1227,6 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 			nullFields = append(nullFields, "deletion_time")
 		}
 	}
-	if d.HasChange("primary_email") {
-		if v, ok := d.GetOk("primary_email"); ok {
-			log.Printf("[DEBUG] Updating user primary_email: %s", d.Get("primary_email").(string))
-			user.PrimaryEmail = v.(string)
-		} else {
-			log.Printf("[DEBUG] Removing user primary_email")
-			user.PrimaryEmail = ""
-			nullFields = append(nullFields, "primary_email")
-		}
-	}
 
 	if d.HasChange("recovery_email") {
 		if v, ok := d.GetOk("recovery_email"); ok {
@@ -898,6 +1296,11 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		if v, ok := d.GetOk("is_suspended"); ok {
 			log.Printf("[DEBUG] Updating user is_suspended: %t", d.Get("is_suspended").(bool))
 			user.Suspended = v.(bool)
+
+			if d.Get("change_password_on_suspend").(bool) {
+				log.Printf("[DEBUG] Forcing a password change at next login for suspended user")
+				user.ChangePasswordAtNextLogin = true
+			}
 		} else {
 			log.Printf("[DEBUG] Removing user is_suspended")
 			user.Suspended = false
@@ -1070,6 +1473,33 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[WARN] Please note, a persistent 503 backend error can mean you need to change your posix values to be unique.")
 		return fmt.Errorf("[ERROR] Error updating user: %s", err)
 	}
+	if fingerprint != "" {
+		d.Set("password", fingerprint)
+	}
+
+	if generatedPassword != "" {
+		if err = userSetGeneratedPassword(d, generatedPassword); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("is_suspended") && updatedUser.Suspended {
+		if d.Get("sign_out_on_suspend").(bool) {
+			err = retry(func() error {
+				return config.directory.Users.SignOut(updatedUser.Id).Do()
+			}, config.TimeoutMinutes)
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error signing out suspended user %s: %s", updatedUser.PrimaryEmail, err)
+			}
+			log.Printf("[INFO] Signed out suspended user %s", updatedUser.PrimaryEmail)
+		}
+
+		if userRevokeAccessOnSuspend(d) {
+			if err = userRevokeAccess(config, updatedUser.Id); err != nil {
+				return err
+			}
+		}
+	}
 
 	if d.HasChange("aliases") {
 
@@ -1079,6 +1509,8 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 				aliases = append(aliases, alias.(string))
 			}
 		}
+		// Never remove the aliases that were left behind by a rename
+		aliases = append(aliases, convertStringSet(d.Get("renamed_aliases").(*schema.Set))...)
 
 		err = userAliasesUpdate(config, updatedUser, aliases)
 		if err != nil {
@@ -1090,6 +1522,190 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceUserRead(d, meta)
 }
 
+// userRename changes the primary email of an existing user. The user keeps its
+// id, G Suite turns the old address into an alias which is either kept (and
+// tracked in renamed_aliases) or removed depending on keep_old_email_as_alias.
+func userRename(d *schema.ResourceData, config *Config) error {
+	o, n := d.GetChange("primary_email")
+	oldEmail := strings.ToLower(o.(string))
+	newEmail := strings.ToLower(n.(string))
+
+	var user *directory.User
+	var err error
+	err = retry(func() error {
+		user, err = config.directory.Users.Get(d.Id()).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error fetching user %s before rename: %s", oldEmail, err)
+	}
+
+	// Make sure we are renaming the user we think we are renaming, if the
+	// address changed outside of terraform the state has to be refreshed first.
+	if strings.ToLower(user.PrimaryEmail) != oldEmail {
+		return fmt.Errorf("[ERROR] Cannot rename user %s: its current primary email is %s, not %s. Refresh the state and try again", d.Id(), user.PrimaryEmail, oldEmail)
+	}
+
+	renamedAliases := d.Get("renamed_aliases").(*schema.Set)
+
+	// Renaming a user to one of its own aliases requires removing the alias first
+	for _, alias := range user.Aliases {
+		if strings.ToLower(alias) != newEmail {
+			continue
+		}
+		log.Printf("[DEBUG] Removing alias %s from user %s, it becomes the primary email", alias, oldEmail)
+		err = retry(func() error {
+			return config.directory.Users.Aliases.Delete(user.Id, alias).Do()
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error removing alias %s before rename: %s", alias, err)
+		}
+		renamedAliases.Remove(alias)
+	}
+
+	// The new address must not belong to somebody else
+	err = retry(func() error {
+		existing, err := config.directory.Users.Get(newEmail).Do()
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			return nil
+		}
+		if err == nil && existing.Id != user.Id {
+			return fmt.Errorf("[ERROR] Cannot rename user %s to %s: the address is already used by user %s", oldEmail, newEmail, existing.Id)
+		}
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Renaming user %s to %s", oldEmail, newEmail)
+	err = retry(func() error {
+		_, err = config.directory.Users.Patch(user.Id, &directory.User{PrimaryEmail: newEmail}).Do()
+		if e, ok := err.(*googleapi.Error); ok {
+			return errors.Wrap(e, e.Body)
+		}
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error renaming user %s to %s: %s", oldEmail, newEmail, err)
+	}
+
+	// Wait for the rename to propagate, the new address should resolve to
+	// the same user.
+	err = retryNotFound(func() error {
+		renamed, err := config.directory.Users.Get(newEmail).Do()
+		if err != nil {
+			return err
+		}
+		if renamed.Id != user.Id || strings.ToLower(renamed.PrimaryEmail) != newEmail {
+			return errors.New("Eventual consistency. Please try again")
+		}
+		return nil
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Taking too long to rename user %s to %s: %s", oldEmail, newEmail, err)
+	}
+
+	if d.Get("keep_old_email_as_alias").(bool) {
+		renamedAliases.Add(oldEmail)
+	} else {
+		log.Printf("[DEBUG] Removing old primary email %s as alias", oldEmail)
+		err = retryNotFound(func() error {
+			return config.directory.Users.Aliases.Delete(user.Id, oldEmail).Do()
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error removing old primary email %s as alias: %s", oldEmail, err)
+		}
+		renamedAliases.Remove(oldEmail)
+	}
+
+	if err = d.Set("renamed_aliases", renamedAliases); err != nil {
+		return fmt.Errorf("Error setting renamed_aliases in state: %s", err.Error())
+	}
+
+	log.Printf("[INFO] Renamed user %s to %s", oldEmail, newEmail)
+	return nil
+}
+
+// userRevokeAccessOnSuspend returns whether the access of the user is to be
+// revoked: when it is created suspended, or when it becomes suspended.
+func userRevokeAccessOnSuspend(d *schema.ResourceData) bool {
+	if !d.Get("revoke_tokens_on_suspend").(bool) || !d.Get("is_suspended").(bool) {
+		return false
+	}
+	return d.IsNewResource() || d.HasChange("is_suspended")
+}
+
+// userRevokeAccess removes all third-party access of a user: OAuth tokens and
+// application-specific passwords are deleted and backup codes invalidated.
+func userRevokeAccess(config *Config, userKey string) error {
+	var tokens *directory.Tokens
+	var err error
+	err = retry(func() error {
+		tokens, err = config.directory.Tokens.List(userKey).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing OAuth tokens of user %s: %s", userKey, err)
+	}
+
+	for _, token := range tokens.Items {
+		log.Printf("[DEBUG] Revoking OAuth token of %s for user %s", token.DisplayText, userKey)
+		err = retry(func() error {
+			return config.directory.Tokens.Delete(userKey, token.ClientId).Do()
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error revoking OAuth token %s of user %s: %s", token.ClientId, userKey, err)
+		}
+	}
+
+	var asps *directory.Asps
+	err = retry(func() error {
+		asps, err = config.directory.Asps.List(userKey).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error listing application-specific passwords of user %s: %s", userKey, err)
+	}
+
+	for _, asp := range asps.Items {
+		log.Printf("[DEBUG] Deleting application-specific password %s of user %s", asp.Name, userKey)
+		err = retry(func() error {
+			return config.directory.Asps.Delete(userKey, asp.CodeId).Do()
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error deleting application-specific password %d of user %s: %s", asp.CodeId, userKey, err)
+		}
+	}
+
+	err = retry(func() error {
+		return config.directory.VerificationCodes.Invalidate(userKey).Do()
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error invalidating verification codes of user %s: %s", userKey, err)
+	}
+
+	log.Printf("[INFO] Revoked tokens, application-specific passwords and verification codes of user %s", userKey)
+	return nil
+}
+
+// splitUserAliases separates the aliases of a user into the ones managed
+// through `aliases` and the ones left behind by a rename.
+func splitUserAliases(d *schema.ResourceData, aliases []string) ([]string, []string) {
+	tracked := d.Get("renamed_aliases").(*schema.Set)
+
+	var managed, renamed []string
+	for _, alias := range aliases {
+		if tracked.Contains(strings.ToLower(alias)) {
+			renamed = append(renamed, strings.ToLower(alias))
+			continue
+		}
+		managed = append(managed, alias)
+	}
+
+	return managed, renamed
+}
+
 func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -1121,7 +1737,9 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("is_suspended", user.Suspended)
 	d.Set("2s_enrolled", user.IsEnrolledIn2Sv)
 	d.Set("2s_enforced", user.IsEnforcedIn2Sv)
-	d.Set("aliases", user.Aliases)
+	aliases, renamedAliases := splitUserAliases(d, user.Aliases)
+	d.Set("aliases", aliases)
+	d.Set("renamed_aliases", renamedAliases)
 	d.Set("agreed_to_terms", user.AgreedToTerms)
 	d.Set("creation_time", user.CreationTime)
 	d.Set("customer_id", user.CustomerId)
@@ -1184,9 +1802,12 @@ func resourceUserImporter(d *schema.ResourceData, meta interface{}) ([]*schema.R
 	d.Set("is_admin", id.IsAdmin)
 	d.Set("is_delegated_admin", id.IsDelegatedAdmin)
 	d.Set("is_suspended", id.Suspended)
+	d.Set("change_password_next_login", true)
 	d.Set("2s_enrolled", id.IsEnrolledIn2Sv)
 	d.Set("2s_enforced", id.IsEnforcedIn2Sv)
-	d.Set("aliases", id.Aliases)
+	aliases, renamedAliases := splitUserAliases(d, id.Aliases)
+	d.Set("aliases", aliases)
+	d.Set("renamed_aliases", renamedAliases)
 	d.Set("agreed_to_terms", id.AgreedToTerms)
 	d.Set("creation_time", id.CreationTime)
 	d.Set("customer_id", id.CustomerId)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -22,6 +23,8 @@ func resourceUserAttributes() *schema.Resource {
 			State: resourceUserAttributesImporter,
 		},
 
+		CustomizeDiff: validateCustomSchemasDiff,
+
 		Schema: map[string]*schema.Schema{
 			"primary_email": {
 				Type:     schema.TypeString,
@@ -40,9 +43,11 @@ func resourceUserAttributes() *schema.Resource {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+						// Only the fields in this JSON object are managed
 						"value": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressEquivalentCustomSchemaValue,
 						},
 					},
 				},
@@ -54,31 +59,15 @@ func resourceUserAttributes() *schema.Resource {
 func resourceUserAttributesCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
-	user := &directory.User{}
+	primaryEmail := strings.ToLower(d.Get("primary_email").(string))
+	log.Printf("[DEBUG] Setting %s: %s", "primary_email", primaryEmail)
 
-	if v, ok := d.GetOk("primary_email"); ok {
-		log.Printf("[DEBUG] Setting %s: %s", "primary_email", v.(string))
-		user.PrimaryEmail = strings.ToLower(v.(string))
-	}
-
-	customSchemas := map[string]googleapi.RawMessage{}
-	for i := 0; i < d.Get("custom_schema.#").(int); i++ {
-		entry := d.Get(fmt.Sprintf("custom_schema.%d", i)).(map[string]interface{})
-		customSchemas[entry["name"].(string)] = []byte(entry["value"].(string))
-
-		log.Printf("[DEBUG] setting entry %v", entry)
-	}
-	if len(customSchemas) > 0 {
-		user.CustomSchemas = customSchemas
-	}
-
-	var err error
-	var updatedUser *directory.User
-	err = retry(func() error {
-		updatedUser, err = config.directory.Users.Patch(user.PrimaryEmail, user).Do()
+	customSchemas, err := userAttributesFromList(d.Get("custom_schema").([]interface{}))
+	if err != nil {
 		return err
-	}, config.TimeoutMinutes)
+	}
 
+	updatedUser, err := userAttributesPatch(config, primaryEmail, customSchemas)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error creating user fields: %s", err)
 	}
@@ -89,19 +78,41 @@ func resourceUserAttributesCreate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceUserAttributesUpdate(d *schema.ResourceData, meta interface{}) error {
-	/*
-		Defined in terms of delete + create, because resourceUserAttributesDelete will delete the information from a user by its stored id - even if we're actually changing the primary_email the gsuite_user_attributes resource points to - and resourceUserAttributesCreate will create the attributes for the current primary_email
-	*/
-	err := resourceUserAttributesDelete(d, meta)
+	config := meta.(*Config)
+
+	if d.HasChange("primary_email") {
+		/*
+			Moving the attributes to another user is done in terms of delete + create, because resourceUserAttributesDelete will delete the information from a user by its stored id and resourceUserAttributesCreate will create the attributes for the current primary_email
+		*/
+		o, _ := d.GetChange("custom_schema")
+		oldSchemas, err := userAttributesFromList(o.([]interface{}))
+		if err != nil {
+			return err
+		}
+		if _, err = userAttributesPatch(config, d.Id(), userAttributesNulled(oldSchemas)); err != nil {
+			return fmt.Errorf("[ERROR] Error deleting user fields: %s", err)
+		}
+		return resourceUserAttributesCreate(d, meta)
+	}
+
+	o, n := d.GetChange("custom_schema")
+	oldSchemas, err := userAttributesFromList(o.([]interface{}))
 	if err != nil {
 		return err
 	}
-
-	err = resourceUserAttributesCreate(d, meta)
+	newSchemas, err := userAttributesFromList(n.([]interface{}))
 	if err != nil {
 		return err
 	}
 
+	changed := userAttributesDiff(oldSchemas, newSchemas)
+	if len(changed) > 0 {
+		if _, err = userAttributesPatch(config, d.Id(), changed); err != nil {
+			return fmt.Errorf("[ERROR] Error updating user fields: %s", err)
+		}
+	}
+
+	log.Printf("[INFO] Updated user fields: %s", d.Get("primary_email").(string))
 	return resourceUserAttributesRead(d, meta)
 }
 
@@ -125,12 +136,48 @@ func resourceUserAttributesRead(d *schema.ResourceData, meta interface{}) error
 	d.SetId(user.Id)
 	d.Set("primary_email", user.PrimaryEmail)
 
-	err, flattenedCustomSchema := flattenCustomSchema(user.CustomSchemas)
-	if err != nil {
-		return err
+	// Only read back the fields this resource manages, other fields of the
+	// same schema may be owned by something else.
+	customSchemas := []map[string]interface{}{}
+	for _, raw := range d.Get("custom_schema").([]interface{}) {
+		entry := raw.(map[string]interface{})
+		name := entry["name"].(string)
+
+		owned := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(entry["value"].(string)), &owned); err != nil {
+			return fmt.Errorf("Error unmarshalling custom attributes in resource: %s", err)
+		}
+
+		current := map[string]interface{}{}
+		if rawSchema, ok := user.CustomSchemas[name]; ok {
+			if err := json.Unmarshal(rawSchema, &current); err != nil {
+				return fmt.Errorf("Error unmarshalling custom attributes of user: %s", err)
+			}
+		}
+
+		fields := map[string]interface{}{}
+		for field := range owned {
+			if value, ok := current[field]; ok {
+				fields[field] = value
+			}
+		}
+
+		value, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		err, ordered := orderValues(string(value))
+		if err != nil {
+			return err
+		}
+
+		customSchemas = append(customSchemas, map[string]interface{}{
+			"name":  name,
+			"value": ordered,
+		})
 	}
 
-	if err = d.Set("custom_schema", flattenedCustomSchema); err != nil {
+	if err = d.Set("custom_schema", customSchemas); err != nil {
 		return fmt.Errorf("Error setting custom_schema in state: %s", err.Error())
 	}
 
@@ -140,41 +187,184 @@ func resourceUserAttributesRead(d *schema.ResourceData, meta interface{}) error
 func resourceUserAttributesDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
-	user := &directory.User{}
+	customSchemas, err := userAttributesFromList(d.Get("custom_schema").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	if _, err = userAttributesPatch(config, d.Id(), userAttributesNulled(customSchemas)); err != nil {
+		return fmt.Errorf("Error deleting user fields: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// userAttributesFromList parses the custom_schema blocks into the fields per
+// schema.
+func userAttributesFromList(entries []interface{}) (map[string]map[string]interface{}, error) {
+	customSchemas := map[string]map[string]interface{}{}
+	for _, raw := range entries {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
 
-	customSchemas := map[string]googleapi.RawMessage{}
-	for i := 0; i < d.Get("custom_schema.#").(int); i++ {
-		entry := d.Get(fmt.Sprintf("custom_schema.%d", i)).(map[string]interface{})
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(entry["value"].(string)), &fields); err != nil {
+			return nil, fmt.Errorf("Error unmarshalling custom attributes in resource: %s", err)
+		}
 
-		customAttributes := map[string]interface{}{}
-		err := json.Unmarshal([]byte(entry["value"].(string)), &customAttributes)
+		name := entry["name"].(string)
+		if _, ok := customSchemas[name]; !ok {
+			customSchemas[name] = map[string]interface{}{}
+		}
+		for field, value := range fields {
+			customSchemas[name][field] = value
+		}
+	}
+	return customSchemas, nil
+}
 
-		if err != nil {
-			return fmt.Errorf("Error unmarshalling custom attributes in resource: %s", err)
+// userAttributesNulled returns the same fields, set to null to clear them.
+func userAttributesNulled(customSchemas map[string]map[string]interface{}) map[string]map[string]interface{} {
+	nulled := map[string]map[string]interface{}{}
+	for name, fields := range customSchemas {
+		nulled[name] = map[string]interface{}{}
+		for field := range fields {
+			nulled[name][field] = nil
 		}
+	}
+	return nulled
+}
 
-		schemaBody := "{"
-		for field := range customAttributes {
-			schemaBody = schemaBody + fmt.Sprintf("\n  \"%s\": null,", field)
+// userAttributesDiff returns the fields that have to be patched to go from
+// the old to the new fields: changed and added fields are set, removed fields
+// are nulled and unchanged fields are left out.
+func userAttributesDiff(oldSchemas, newSchemas map[string]map[string]interface{}) map[string]map[string]interface{} {
+	changed := map[string]map[string]interface{}{}
+	add := func(name, field string, value interface{}) {
+		if _, ok := changed[name]; !ok {
+			changed[name] = map[string]interface{}{}
 		}
-		// remove the training comma
-		schemaBody = schemaBody[:len(schemaBody)-1] + "\n}"
+		changed[name][field] = value
+	}
+
+	for name, fields := range newSchemas {
+		for field, value := range fields {
+			oldValue, ok := oldSchemas[name][field]
+			if !ok || !customSchemaFieldValuesEqual(oldValue, value) {
+				add(name, field, value)
+			}
+		}
+	}
+
+	for name, fields := range oldSchemas {
+		for field := range fields {
+			if _, ok := newSchemas[name][field]; !ok {
+				add(name, field, nil)
+			}
+		}
+	}
+
+	return changed
+}
 
-		customSchemas[entry["name"].(string)] = []byte(schemaBody)
+// userAttributesPatch patches only the given custom schema fields of a user.
+func userAttributesPatch(config *Config, userKey string, customSchemas map[string]map[string]interface{}) (*directory.User, error) {
+	user := &directory.User{
+		CustomSchemas: map[string]googleapi.RawMessage{},
+	}
+	for name, fields := range customSchemas {
+		value, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Patching custom schema %s: %s", name, value)
+		user.CustomSchemas[name] = value
 	}
-	user.CustomSchemas = customSchemas
 
+	var updatedUser *directory.User
 	var err error
 	err = retry(func() error {
-		_, err = config.directory.Users.Update(d.Id(), user).Do()
+		updatedUser, err = config.directory.Users.Patch(userKey, user).Do()
 		return err
 	}, config.TimeoutMinutes)
-	if err != nil {
-		return fmt.Errorf("Error deleting user fields: %s", err)
+
+	return updatedUser, err
+}
+
+// customSchemaFieldValuesEqual compares two field values, the API returns
+// some scalars as strings (e.g. numbers) so those are compared by their
+// string representation.
+func customSchemaFieldValuesEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		// Multi valued fields are compared regardless of their order
+		av, bv = sortedCustomSchemaValues(av), sortedCustomSchemaValues(bv)
+		for i := range av {
+			if !customSchemaFieldValuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			if !customSchemaFieldValuesEqual(v, bv[k]) {
+				return false
+			}
+		}
+		return true
+	case nil:
+		return b == nil
+	default:
+		if b == nil {
+			return false
+		}
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}
+}
+
+// sortedCustomSchemaValues returns a copy of the values of a multi valued
+// field, sorted by their "value" key.
+func sortedCustomSchemaValues(values []interface{}) []interface{} {
+	key := func(v interface{}) string {
+		if entry, ok := v.(map[string]interface{}); ok {
+			return fmt.Sprint(entry["value"])
+		}
+		return fmt.Sprint(v)
 	}
 
-	d.SetId("")
-	return nil
+	sorted := make([]interface{}, len(values))
+	copy(sorted, values)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return key(sorted[i]) < key(sorted[j])
+	})
+	return sorted
+}
+
+func suppressEquivalentCustomSchemaValue(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+
+	var oldFields, newFields map[string]interface{}
+	if err := json.Unmarshal([]byte(old), &oldFields); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newFields); err != nil {
+		return false
+	}
+
+	return customSchemaFieldValuesEqual(oldFields, newFields)
 }
 
 // Allow importing using any key (id, email, alias)
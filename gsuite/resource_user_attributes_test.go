@@ -0,0 +1,69 @@
+package gsuite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUserAttributesDiff(t *testing.T) {
+	oldSchemas := map[string]map[string]interface{}{
+		"Employee": {
+			"cost_center": "123",
+			"level":       float64(3),
+			"legacy":      "x",
+		},
+		"Badge": {
+			"number": "42",
+		},
+	}
+	newSchemas := map[string]map[string]interface{}{
+		"Employee": {
+			"cost_center": "124",
+			"level":       "3",
+			"start_date":  "2020-01-31",
+		},
+	}
+
+	expected := map[string]map[string]interface{}{
+		"Employee": {
+			"cost_center": "124",
+			"legacy":      nil,
+			"start_date":  "2020-01-31",
+		},
+		"Badge": {
+			"number": nil,
+		},
+	}
+
+	changed := userAttributesDiff(oldSchemas, newSchemas)
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected %v, got %v", expected, changed)
+	}
+
+	if changed := userAttributesDiff(oldSchemas, oldSchemas); len(changed) != 0 {
+		t.Errorf("expected no changes, got %v", changed)
+	}
+}
+
+func TestSuppressEquivalentCustomSchemaValue(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{`{"a":"1","b":true}`, `{"b": true, "a": "1"}`, true},
+		{`{"level":"3"}`, `{"level":3}`, true},
+		{`{"is_manager":"true"}`, `{"is_manager":true}`, true},
+		{`{"emails":[{"type":"work","value":"b"},{"type":"work","value":"a"}]}`, `{"emails":[{"value":"a","type":"work"},{"value":"b","type":"work"}]}`, true},
+		{`{"level":"3"}`, `{"level":4}`, false},
+		{`{"a":"1"}`, `{"a":"1","b":"2"}`, false},
+		{`{"emails":[{"type":"work","value":"a"}]}`, `{"emails":[{"type":"home","value":"a"}]}`, false},
+		{`{"a":"1"}`, ``, false},
+	}
+
+	for _, testCase := range testCases {
+		if suppress := suppressEquivalentCustomSchemaValue("", testCase.old, testCase.new, nil); suppress != testCase.suppress {
+			t.Errorf("expected suppress %t for %s and %s", testCase.suppress, testCase.old, testCase.new)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package gsuite
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/licensing/v1"
+)
+
+func resourceUserLicense() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserLicenseCreate,
+		Read:   resourceUserLicenseRead,
+		Update: resourceUserLicenseUpdate,
+		Delete: resourceUserLicenseDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUserLicenseImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// e.g. Google-Apps, 101033 for Google Voice
+			"product_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Changing the SKU reassigns the license of the user
+			"sku_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.ToLower(old) == strings.ToLower(new)
+				},
+				ValidateFunc: validateEmail,
+			},
+
+			"product_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"sku_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etags": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUserLicenseCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	licensingSvc, err := config.licensingService()
+	if err != nil {
+		return err
+	}
+
+	productID, skuID := d.Get("product_id").(string), d.Get("sku_id").(string)
+	user := strings.ToLower(d.Get("user").(string))
+
+	// Retrying for 404's, the user may have just been created
+	err = retryNotFound(func() error {
+		_, err = licensingSvc.LicenseAssignments.Insert(productID, skuID, &licensing.LicenseAssignmentInsert{UserId: user}).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error assigning license %s/%s to %s: %s", productID, skuID, user, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", productID, user))
+	log.Printf("[INFO] Assigned license %s/%s to %s", productID, skuID, user)
+
+	return resourceUserLicenseRead(d, meta)
+}
+
+func resourceUserLicenseUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if !d.HasChange("sku_id") {
+		return resourceUserLicenseRead(d, meta)
+	}
+
+	licensingSvc, err := config.licensingService()
+	if err != nil {
+		return err
+	}
+
+	productID, user := d.Get("product_id").(string), strings.ToLower(d.Get("user").(string))
+	oldSku, newSku := d.GetChange("sku_id")
+
+	assignment := &licensing.LicenseAssignment{
+		SkuId: newSku.(string),
+	}
+
+	log.Printf("[DEBUG] Reassigning license of %s from %s to %s", user, oldSku, newSku)
+	err = retry(func() error {
+		_, err = licensingSvc.LicenseAssignments.Patch(productID, oldSku.(string), user, assignment).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error reassigning license of %s from %s to %s: %s", user, oldSku, newSku, err)
+	}
+
+	return resourceUserLicenseRead(d, meta)
+}
+
+func resourceUserLicenseRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	licensingSvc, err := config.licensingService()
+	if err != nil {
+		return err
+	}
+
+	productID, skuID := d.Get("product_id").(string), d.Get("sku_id").(string)
+	user := strings.ToLower(d.Get("user").(string))
+
+	var assignment *licensing.LicenseAssignment
+	if skuID != "" {
+		err = retry(func() error {
+			assignment, err = licensingSvc.LicenseAssignments.Get(productID, skuID, user).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			assignment, err = nil, nil
+		}
+		if err != nil {
+			return handleNotFoundError(err, d, fmt.Sprintf("License %s/%s of %s", productID, skuID, user))
+		}
+	}
+
+	// The SKU changed outside of Terraform, or by a failed update, or it is
+	// not known yet after an import
+	if assignment == nil {
+		log.Printf("[DEBUG] License %s/%s of %s not found, looking up the license of the product", productID, skuID, user)
+		assignment, err = userLicenseFind(config, licensingSvc, productID, user)
+		if err != nil {
+			return err
+		}
+		if assignment == nil {
+			log.Printf("[WARN] Removing license %s of %s because it's gone", productID, user)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	d.Set("product_id", assignment.ProductId)
+	d.Set("sku_id", assignment.SkuId)
+	d.Set("user", strings.ToLower(assignment.UserId))
+	d.Set("product_name", assignment.ProductName)
+	d.Set("sku_name", assignment.SkuName)
+	d.Set("etags", assignment.Etags)
+
+	return nil
+}
+
+func resourceUserLicenseDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	licensingSvc, err := config.licensingService()
+	if err != nil {
+		return err
+	}
+
+	err = retry(func() error {
+		_, err = licensingSvc.LicenseAssignments.Delete(d.Get("product_id").(string), d.Get("sku_id").(string),
+			strings.ToLower(d.Get("user").(string))).Do()
+		return err
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error deleting license assignment: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// userLicenseFind returns the license of the product assigned to the user,
+// whatever its SKU, or nil.
+func userLicenseFind(config *Config, licensingSvc *licensing.Service, productID, user string) (*licensing.LicenseAssignment, error) {
+	customerID, err := config.resolveCustomerId()
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []*licensing.LicenseAssignment
+	err = retry(func() error {
+		assignments = nil
+		collect := func(list *licensing.LicenseAssignmentList) error {
+			assignments = append(assignments, list.Items...)
+			return nil
+		}
+		return licensingSvc.LicenseAssignments.ListForProduct(productID, customerID).
+			MaxResults(1000).Pages(context.Background(), collect)
+	}, config.TimeoutMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error listing license assignments of %s: %s", productID, err)
+	}
+
+	return userLicenseAssignment(assignments, user), nil
+}
+
+// userLicenseAssignment returns the assignment of the user, or nil.
+func userLicenseAssignment(assignments []*licensing.LicenseAssignment, user string) *licensing.LicenseAssignment {
+	for _, assignment := range assignments {
+		if strings.EqualFold(assignment.UserId, user) {
+			return assignment
+		}
+	}
+	return nil
+}
+
+// Allow importing using [product id]/[user email], the ID of the resource. The
+// SKU is read from the license of the user.
+func resourceUserLicenseImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("[WARN] Import via [product id]/[user email]")
+	}
+
+	user := strings.ToLower(s[1])
+	d.SetId(fmt.Sprintf("%s/%s", s[0], user))
+	d.Set("product_id", s[0])
+	d.Set("user", user)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,21 @@
+package gsuite
+
+import (
+	"testing"
+
+	"google.golang.org/api/licensing/v1"
+)
+
+func TestUserLicenseAssignment(t *testing.T) {
+	assignments := []*licensing.LicenseAssignment{
+		{UserId: "jane@domain.ext", ProductId: "Google-Apps", SkuId: "1010020025"},
+		{UserId: "John@domain.ext", ProductId: "Google-Apps", SkuId: "1010020020"},
+	}
+
+	if found := userLicenseAssignment(assignments, "john@domain.ext"); found == nil || found.SkuId != "1010020020" {
+		t.Errorf("expected the license of john@domain.ext, got %v", found)
+	}
+	if found := userLicenseAssignment(assignments, "ceo@domain.ext"); found != nil {
+		t.Errorf("expected no license, got %v", found)
+	}
+}
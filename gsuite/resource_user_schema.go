@@ -1,14 +1,19 @@
 package gsuite
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/mail"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 )
 
 func resourceUserSchema() *schema.Resource {
@@ -21,7 +26,23 @@ func resourceUserSchema() *schema.Resource {
 			State: resourceUserSchemaImporter,
 		},
 
+		CustomizeDiff: resourceUserSchemaCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
+			// Removing or retyping fields breaks the values stored on users,
+			// these changes have to be allowed explicitly.
+			"allow_destructive_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Count the users holding values for removed or retyped fields
+			// when planning a destructive change.
+			"scan_affected_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"schema_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -277,6 +298,286 @@ func resourceUserSchemaImporter(d *schema.ResourceData, meta interface{}) ([]*sc
 	return []*schema.ResourceData{d}, nil
 }
 
+type userSchemaFieldChangeKind string
+
+const (
+	userSchemaFieldAdded    userSchemaFieldChangeKind = "added"
+	userSchemaFieldRenamed  userSchemaFieldChangeKind = "renamed"
+	userSchemaFieldUpdated  userSchemaFieldChangeKind = "updated"
+	userSchemaFieldRetyped  userSchemaFieldChangeKind = "retyped"
+	userSchemaFieldNarrowed userSchemaFieldChangeKind = "narrowed"
+	userSchemaFieldRemoved  userSchemaFieldChangeKind = "removed"
+)
+
+type userSchemaFieldChange struct {
+	kind      userSchemaFieldChangeKind
+	fieldName string
+	detail    string
+}
+
+func (c userSchemaFieldChange) destructive() bool {
+	return c.kind == userSchemaFieldRetyped || c.kind == userSchemaFieldNarrowed || c.kind == userSchemaFieldRemoved
+}
+
+func (c userSchemaFieldChange) String() string {
+	if c.detail == "" {
+		return fmt.Sprintf("%s %s", c.fieldName, c.kind)
+	}
+	return fmt.Sprintf("%s %s (%s)", c.fieldName, c.kind, c.detail)
+}
+
+// userSchemaRangeBound returns a bound of a `range`, an absent bound is
+// unbounded.
+func userSchemaRangeBound(r map[string]interface{}, key string) (float64, bool) {
+	v, ok := r[key].(string)
+	if !ok || v == "" {
+		return 0, false
+	}
+	bound, err := strconv.ParseFloat(v, 64)
+	return bound, err == nil
+}
+
+// userSchemaRangeNarrowed tells whether the new range of a field excludes
+// values the old range allowed.
+func userSchemaRangeNarrowed(oldRange, newRange map[string]interface{}) bool {
+	oldMin, oldHasMin := userSchemaRangeBound(oldRange, "min_value")
+	newMin, newHasMin := userSchemaRangeBound(newRange, "min_value")
+	if newHasMin && (!oldHasMin || newMin > oldMin) {
+		return true
+	}
+
+	oldMax, oldHasMax := userSchemaRangeBound(oldRange, "max_value")
+	newMax, newHasMax := userSchemaRangeBound(newRange, "max_value")
+	return newHasMax && (!oldHasMax || newMax < oldMax)
+}
+
+// classifyUserSchemaFieldChanges compares two `field` lists by field_name.
+// Changing the type or multi_valued of a field, narrowing its range, or
+// removing it, makes the values stored on users unusable, anything else is
+// safe.
+func classifyUserSchemaFieldChanges(oldFields, newFields []interface{}) []userSchemaFieldChange {
+	byName := func(fields []interface{}) (map[string]map[string]interface{}, []string) {
+		m := map[string]map[string]interface{}{}
+		names := []string{}
+		for _, raw := range fields {
+			field, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name := field["field_name"].(string)
+			m[name] = field
+			names = append(names, name)
+		}
+		return m, names
+	}
+
+	oldMap, oldNames := byName(oldFields)
+	newMap, newNames := byName(newFields)
+
+	changes := []userSchemaFieldChange{}
+	for _, name := range oldNames {
+		oldField := oldMap[name]
+		newField, ok := newMap[name]
+		if !ok {
+			changes = append(changes, userSchemaFieldChange{kind: userSchemaFieldRemoved, fieldName: name})
+			continue
+		}
+
+		if oldField["field_type"] != newField["field_type"] {
+			changes = append(changes, userSchemaFieldChange{
+				kind:      userSchemaFieldRetyped,
+				fieldName: name,
+				detail:    fmt.Sprintf("%v -> %v", oldField["field_type"], newField["field_type"]),
+			})
+			continue
+		}
+		if oldField["multi_valued"] != newField["multi_valued"] {
+			changes = append(changes, userSchemaFieldChange{
+				kind:      userSchemaFieldRetyped,
+				fieldName: name,
+				detail:    fmt.Sprintf("multi_valued %v -> %v", oldField["multi_valued"], newField["multi_valued"]),
+			})
+			continue
+		}
+
+		// An empty display_name defaults to the existing one
+		if newField["display_name"] != "" && oldField["display_name"] != newField["display_name"] {
+			changes = append(changes, userSchemaFieldChange{
+				kind:      userSchemaFieldRenamed,
+				fieldName: name,
+				detail:    fmt.Sprintf("%q -> %q", oldField["display_name"], newField["display_name"]),
+			})
+		}
+
+		oldRange, _ := oldField["range"].(map[string]interface{})
+		newRange, _ := newField["range"].(map[string]interface{})
+		if userSchemaRangeNarrowed(oldRange, newRange) {
+			changes = append(changes, userSchemaFieldChange{
+				kind:      userSchemaFieldNarrowed,
+				fieldName: name,
+				detail:    fmt.Sprintf("range %v -> %v", oldRange, newRange),
+			})
+		} else if userSchemaRangeNarrowed(newRange, oldRange) {
+			changes = append(changes, userSchemaFieldChange{
+				kind:      userSchemaFieldUpdated,
+				fieldName: name,
+				detail:    fmt.Sprintf("range %v -> %v", oldRange, newRange),
+			})
+		}
+
+		for _, attr := range []string{"indexed", "read_access_type"} {
+			if oldField[attr] != newField[attr] {
+				changes = append(changes, userSchemaFieldChange{
+					kind:      userSchemaFieldUpdated,
+					fieldName: name,
+					detail:    fmt.Sprintf("%s %v -> %v", attr, oldField[attr], newField[attr]),
+				})
+			}
+		}
+	}
+
+	for _, name := range newNames {
+		if _, ok := oldMap[name]; !ok {
+			changes = append(changes, userSchemaFieldChange{kind: userSchemaFieldAdded, fieldName: name})
+		}
+	}
+
+	return changes
+}
+
+// resourceUserSchemaCustomizeDiff blocks changes to the fields of a schema
+// that would break the values stored on users, unless they are explicitly
+// allowed with allow_destructive_changes.
+func resourceUserSchemaCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("field") || !(d.HasChange("field") || d.HasChange("schema_name")) {
+		return nil
+	}
+
+	o, n := d.GetChange("field")
+	changes := classifyUserSchemaFieldChanges(o.([]interface{}), n.([]interface{}))
+
+	destructive := []string{}
+	affectedFields := []string{}
+
+	// Values on users are stored under the schema name
+	if d.HasChange("schema_name") {
+		oldName, newName := d.GetChange("schema_name")
+		destructive = append(destructive, fmt.Sprintf("schema_name %q -> %q", oldName, newName))
+		for _, raw := range o.([]interface{}) {
+			if field, ok := raw.(map[string]interface{}); ok {
+				affectedFields = append(affectedFields, field["field_name"].(string))
+			}
+		}
+	}
+
+	for _, change := range changes {
+		log.Printf("[DEBUG] Schema %s: field %s", d.Id(), change)
+		if change.destructive() {
+			destructive = append(destructive, change.String())
+			if !d.HasChange("schema_name") {
+				affectedFields = append(affectedFields, change.fieldName)
+			}
+		}
+	}
+	if len(destructive) == 0 {
+		return nil
+	}
+
+	oldName, _ := d.GetChange("schema_name")
+	message := fmt.Sprintf("destructive changes to schema %q: %s", oldName.(string), strings.Join(destructive, ", "))
+
+	if d.Get("scan_affected_users").(bool) && meta != nil {
+		count, err := countUsersWithSchemaFields(meta.(*Config), oldName.(string), affectedFields)
+		if err != nil {
+			return fmt.Errorf("%s; unable to count the affected users: %s", message, err)
+		}
+		message = fmt.Sprintf("%s; %d user(s) hold values for these fields", message, count)
+	}
+
+	if d.Get("allow_destructive_changes").(bool) {
+		log.Printf("[WARN] Allowing %s", message)
+		return nil
+	}
+
+	return fmt.Errorf("%s. Set allow_destructive_changes = true to apply them anyway", message)
+}
+
+// countUsersWithSchemaFields returns the number of users with a value for any
+// of the given fields of a custom schema.
+func countUsersWithSchemaFields(config *Config, schemaName string, fieldNames []string) (int, error) {
+	count := 0
+	token := ""
+	for paginate := true; paginate; {
+		var users *directory.Users
+		var err error
+		err = retry(func() error {
+			users, err = config.directory.Users.List().
+				Customer(config.CustomerId).
+				Projection("custom").
+				CustomFieldMask(schemaName).
+				MaxResults(500).
+				PageToken(token).
+				Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return count, err
+		}
+
+		for _, user := range users.Users {
+			raw, ok := user.CustomSchemas[schemaName]
+			if !ok {
+				continue
+			}
+			values := map[string]interface{}{}
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return count, err
+			}
+			for _, fieldName := range fieldNames {
+				if v, ok := values[fieldName]; ok && v != nil {
+					count++
+					break
+				}
+			}
+		}
+
+		token = users.NextPageToken
+		paginate = token != ""
+	}
+
+	return count, nil
+}
+
+// flattenUserSchemaFields is the inverse of getUserSchemaFieldSpecs.
+func flattenUserSchemaFields(specs []*directory.SchemaFieldSpec) []map[string]interface{} {
+	fields := make([]map[string]interface{}, 0, len(specs))
+	for _, spec := range specs {
+		indexed := true
+		if spec.Indexed != nil {
+			indexed = *spec.Indexed
+		}
+
+		field := map[string]interface{}{
+			"field_name":       spec.FieldName,
+			"display_name":     spec.DisplayName,
+			"field_type":       spec.FieldType,
+			"multi_valued":     spec.MultiValued,
+			"read_access_type": spec.ReadAccessType,
+			"indexed":          indexed,
+		}
+
+		if spec.NumericIndexingSpec != nil {
+			field["range"] = map[string]interface{}{
+				"min_value": strconv.FormatFloat(spec.NumericIndexingSpec.MinValue, 'f', -1, 64),
+				"max_value": strconv.FormatFloat(spec.NumericIndexingSpec.MaxValue, 'f', -1, 64),
+			}
+		}
+
+		fields = append(fields, field)
+	}
+	return fields
+}
+
 func getUserSchemaFieldSpecs(d *schema.ResourceData) ([]*directory.SchemaFieldSpec, error) {
 	var specs []*directory.SchemaFieldSpec
 	for i := 0; i < d.Get("field.#").(int); i++ {
@@ -350,3 +651,205 @@ func getUserSchemaFieldSpecs(d *schema.ResourceData) ([]*directory.SchemaFieldSp
 
 	return specs, nil
 }
+
+// validateCustomSchemasDiff checks the custom_schema values of a user against
+// the definitions of the schemas they belong to, so that typos and wrongly
+// typed values are reported at plan time instead of on apply.
+func validateCustomSchemasDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("custom_schema") || !d.NewValueKnown("custom_schema") || meta == nil {
+		return nil
+	}
+	config := meta.(*Config)
+
+	var problems []string
+	for i := 0; i < d.Get("custom_schema.#").(int); i++ {
+		key := fmt.Sprintf("custom_schema.%d", i)
+		if !d.NewValueKnown(key+".name") || !d.NewValueKnown(key+".value") {
+			continue
+		}
+		name := d.Get(key + ".name").(string)
+		value := d.Get(key + ".value").(string)
+
+		var userSchema *directory.Schema
+		var err error
+		err = retry(func() error {
+			userSchema, err = config.directory.Schemas.Get(config.CustomerId, name).Do()
+			return err
+		}, config.TimeoutMinutes)
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			// The schema might be created in this same run
+			log.Printf("[WARN] Not validating %s, schema %q does not exist yet", key, name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error fetching schema %q to validate %s: %s", name, key, err)
+		}
+
+		for _, problem := range validateCustomSchemaValue(userSchema, value) {
+			problems = append(problems, fmt.Sprintf("%s.value (schema %q): %s", key, name, problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid custom_schema:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// validateCustomSchemaValue validates the JSON value of a custom schema against
+// its field definitions, returning one message per offending field.
+func validateCustomSchemaValue(userSchema *directory.Schema, value string) []string {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return []string{fmt.Sprintf("value is not a JSON object: %s", err)}
+	}
+
+	fields := map[string]*directory.SchemaFieldSpec{}
+	for _, field := range userSchema.Fields {
+		fields[field.FieldName] = field
+	}
+
+	// Sort for stable diagnostics
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			known := make([]string, 0, len(fields))
+			for fieldName := range fields {
+				known = append(known, fieldName)
+			}
+			sort.Strings(known)
+			problems = append(problems, fmt.Sprintf("field %q is not defined, known fields are: %s", name, strings.Join(known, ", ")))
+			continue
+		}
+
+		fieldValue := values[name]
+		// null clears a field
+		if fieldValue == nil {
+			continue
+		}
+
+		if !field.MultiValued {
+			if err := validateCustomSchemaFieldValue(field, fieldValue); err != nil {
+				problems = append(problems, fmt.Sprintf("field %q: %s", name, err))
+			}
+			continue
+		}
+
+		entries, ok := fieldValue.([]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("field %q is multi valued, expected a list of {\"value\": ...} objects", name))
+			continue
+		}
+		for j, rawEntry := range entries {
+			entry, ok := rawEntry.(map[string]interface{})
+			if !ok {
+				problems = append(problems, fmt.Sprintf("field %q[%d]: expected a {\"value\": ...} object", name, j))
+				continue
+			}
+			if err := validateCustomSchemaFieldValue(field, entry["value"]); err != nil {
+				problems = append(problems, fmt.Sprintf("field %q[%d]: %s", name, j, err))
+			}
+		}
+	}
+
+	return problems
+}
+
+// userSchemaFieldRange returns the bounds of a numeric field which are
+// present, or nil. The API omits bounds of 0, so an omitted bound is a bound
+// of 0, unless that contradicts the other bound, e.g. a minimum of 1 without
+// a maximum.
+func userSchemaFieldRange(spec *directory.SchemaFieldSpecNumericIndexingSpec) (*float64, *float64) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	minValue, maxValue := spec.MinValue, spec.MaxValue
+	if minValue > maxValue {
+		if maxValue == 0 {
+			return &minValue, nil
+		}
+		if minValue == 0 {
+			return nil, &maxValue
+		}
+	}
+	return &minValue, &maxValue
+}
+
+// validateCustomSchemaFieldValue validates a single value against the type and
+// range of a field. Values may be native JSON types or their string form.
+func validateCustomSchemaFieldValue(field *directory.SchemaFieldSpec, value interface{}) error {
+	str, isString := value.(string)
+
+	switch field.FieldType {
+	case "BOOL":
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+		if _, err := strconv.ParseBool(str); isString && err == nil {
+			return nil
+		}
+		return fmt.Errorf("expected a BOOL, got %v", value)
+
+	case "INT64", "DOUBLE":
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case string:
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("expected a %s, got %q", field.FieldType, v)
+			}
+			number = parsed
+		default:
+			return fmt.Errorf("expected a %s, got %v", field.FieldType, value)
+		}
+
+		if field.FieldType == "INT64" && number != float64(int64(number)) {
+			return fmt.Errorf("expected an INT64, got %v", value)
+		}
+
+		minValue, maxValue := userSchemaFieldRange(field.NumericIndexingSpec)
+		if minValue != nil && number < *minValue {
+			return fmt.Errorf("%v is lower than the minimum %v", value, *minValue)
+		}
+		if maxValue != nil && number > *maxValue {
+			return fmt.Errorf("%v is higher than the maximum %v", value, *maxValue)
+		}
+		return nil
+
+	case "DATE":
+		if !isString {
+			return fmt.Errorf("expected a DATE (YYYY-MM-DD), got %v", value)
+		}
+		if _, err := time.Parse("2006-01-02", str); err != nil {
+			return fmt.Errorf("expected a DATE (YYYY-MM-DD), got %q", str)
+		}
+		return nil
+
+	case "EMAIL":
+		if !isString {
+			return fmt.Errorf("expected an EMAIL, got %v", value)
+		}
+		if _, err := mail.ParseAddress(str); err != nil {
+			return fmt.Errorf("expected an EMAIL, got %q", str)
+		}
+		return nil
+
+	default:
+		// STRING and PHONE
+		if !isString {
+			return fmt.Errorf("expected a %s, got %v", field.FieldType, value)
+		}
+		return nil
+	}
+}
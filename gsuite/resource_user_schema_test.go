@@ -0,0 +1,131 @@
+package gsuite
+
+import (
+	"strings"
+	"testing"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestValidateCustomSchemaValue(t *testing.T) {
+	userSchema := &directory.Schema{
+		SchemaName: "Employee",
+		Fields: []*directory.SchemaFieldSpec{
+			{FieldName: "cost_center", FieldType: "STRING"},
+			{FieldName: "is_manager", FieldType: "BOOL"},
+			{FieldName: "level", FieldType: "INT64", NumericIndexingSpec: &directory.SchemaFieldSpecNumericIndexingSpec{MinValue: 1, MaxValue: 10}},
+			{FieldName: "seniority", FieldType: "INT64", NumericIndexingSpec: &directory.SchemaFieldSpecNumericIndexingSpec{MinValue: 1}},
+			{FieldName: "offset", FieldType: "DOUBLE", NumericIndexingSpec: &directory.SchemaFieldSpecNumericIndexingSpec{MaxValue: -1}},
+			{FieldName: "rating", FieldType: "INT64", NumericIndexingSpec: &directory.SchemaFieldSpecNumericIndexingSpec{MaxValue: 5}},
+			{FieldName: "debt", FieldType: "DOUBLE", NumericIndexingSpec: &directory.SchemaFieldSpecNumericIndexingSpec{MinValue: -10}},
+			{FieldName: "start_date", FieldType: "DATE"},
+			{FieldName: "backup", FieldType: "EMAIL", MultiValued: true},
+		},
+	}
+
+	testCases := []struct {
+		value    string
+		problems []string
+	}{
+		{`{"cost_center": "123", "is_manager": true, "level": 3, "start_date": "2020-01-31"}`, nil},
+		{`{"is_manager": "false", "level": "10", "backup": [{"value": "a@domain.ext", "type": "work"}]}`, nil},
+		{`{"cost_center": null}`, nil},
+		{`{"cost_centr": "123"}`, []string{`field "cost_centr" is not defined`}},
+		{`{"is_manager": "yes"}`, []string{`field "is_manager": expected a BOOL`}},
+		{`{"level": 3.5}`, []string{`field "level": expected an INT64`}},
+		{`{"level": 11}`, []string{`field "level": 11 is higher than the maximum 10`}},
+		{`{"seniority": 1000}`, nil},
+		{`{"seniority": 0}`, []string{`field "seniority": 0 is lower than the minimum 1`}},
+		{`{"offset": -1000.5}`, nil},
+		{`{"offset": 0}`, []string{`field "offset": 0 is higher than the maximum -1`}},
+		{`{"rating": 0}`, nil},
+		{`{"rating": -1}`, []string{`field "rating": -1 is lower than the minimum 0`}},
+		{`{"debt": -10}`, nil},
+		{`{"debt": 0.5}`, []string{`field "debt": 0.5 is higher than the maximum 0`}},
+		{`{"start_date": "31/01/2020"}`, []string{`field "start_date": expected a DATE`}},
+		{`{"backup": "a@domain.ext"}`, []string{`field "backup" is multi valued`}},
+		{`{"backup": [{"value": "not an email"}]}`, []string{`field "backup"[0]: expected an EMAIL`}},
+		{`{"cost_center": 123, "level": 0}`, []string{`field "cost_center": expected a STRING`, `field "level": 0 is lower than the minimum 1`}},
+		{`not json`, []string{`value is not a JSON object`}},
+	}
+
+	for _, testCase := range testCases {
+		problems := validateCustomSchemaValue(userSchema, testCase.value)
+		if len(problems) != len(testCase.problems) {
+			t.Errorf("expected %d problems for %s, got %v", len(testCase.problems), testCase.value, problems)
+			continue
+		}
+		for i, problem := range problems {
+			if !strings.HasPrefix(problem, testCase.problems[i]) {
+				t.Errorf("expected problem %q for %s, got %q", testCase.problems[i], testCase.value, problem)
+			}
+		}
+	}
+}
+
+func TestClassifyUserSchemaFieldChanges(t *testing.T) {
+	field := func(name, fieldType, displayName string, multiValued bool) interface{} {
+		return map[string]interface{}{
+			"field_name":       name,
+			"field_type":       fieldType,
+			"display_name":     displayName,
+			"multi_valued":     multiValued,
+			"indexed":          true,
+			"read_access_type": "ADMINS_AND_SELF",
+		}
+	}
+	ranged := func(name, minValue, maxValue string) interface{} {
+		f := field(name, "INT64", name, false).(map[string]interface{})
+		f["range"] = map[string]interface{}{"min_value": minValue, "max_value": maxValue}
+		return f
+	}
+
+	oldFields := []interface{}{
+		field("cost_center", "STRING", "Cost center", false),
+		field("level", "INT64", "Level", false),
+		field("backup", "EMAIL", "Backup", true),
+		field("legacy", "STRING", "Legacy", false),
+		ranged("grade", "1", "10"),
+		ranged("score", "0", "100"),
+		field("age", "INT64", "age", false),
+	}
+	newFields := []interface{}{
+		field("cost_center", "STRING", "Cost centre", false),
+		field("level", "DOUBLE", "Level", false),
+		field("backup", "EMAIL", "", false),
+		field("start_date", "DATE", "Start date", false),
+		ranged("grade", "2", "10"),
+		ranged("score", "-10", "200"),
+		ranged("age", "0", "150"),
+	}
+
+	expected := []userSchemaFieldChange{
+		{kind: userSchemaFieldRenamed, fieldName: "cost_center"},
+		{kind: userSchemaFieldRetyped, fieldName: "level"},
+		{kind: userSchemaFieldRetyped, fieldName: "backup"},
+		{kind: userSchemaFieldRemoved, fieldName: "legacy"},
+		{kind: userSchemaFieldNarrowed, fieldName: "grade"},
+		{kind: userSchemaFieldUpdated, fieldName: "score"},
+		{kind: userSchemaFieldNarrowed, fieldName: "age"},
+		{kind: userSchemaFieldAdded, fieldName: "start_date"},
+	}
+
+	changes := classifyUserSchemaFieldChanges(oldFields, newFields)
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), changes)
+	}
+
+	for i, change := range changes {
+		if change.kind != expected[i].kind || change.fieldName != expected[i].fieldName {
+			t.Errorf("expected %s %s, got %s", expected[i].fieldName, expected[i].kind, change)
+		}
+		destructive := change.kind == userSchemaFieldRetyped || change.kind == userSchemaFieldNarrowed || change.kind == userSchemaFieldRemoved
+		if change.destructive() != destructive {
+			t.Errorf("unexpected destructive() for %s", change)
+		}
+	}
+
+	if changes := classifyUserSchemaFieldChanges(oldFields, oldFields); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
@@ -0,0 +1,166 @@
+package gsuite
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestValidatePasswordHash(t *testing.T) {
+
+	testCases := []struct {
+		hashFunction string
+		password     string
+		success      bool
+	}{
+		{"MD5", "5f4dcc3b5aa765d61d8327deb882cf99", true},
+		{"MD5", "5f4dcc3b5aa765d61d8327deb882cf9", false},
+		{"SHA-1", "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", true},
+		{"SHA-1", "5f4dcc3b5aa765d61d8327deb882cf99", false},
+		{"crypt", "$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/", true},
+		{"crypt", "$6$rounds=5000$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/", true},
+		{"crypt", "$1$saltsalt$qjXMvbEw8oaL.CzflDugX/", true},
+		{"crypt", "abJnggxhB/yWI", true},
+		{"crypt", "password", false},
+		{"bcrypt", "$2a$10$abcdefghijklmnopqrstuv", false},
+	}
+
+	for _, testCase := range testCases {
+		err := validatePasswordHash(testCase.hashFunction, testCase.password)
+		if err != nil && testCase.success {
+			t.Errorf("expected a valid %s hash for %s: %s", testCase.hashFunction, testCase.password, err)
+		} else if err == nil && !testCase.success {
+			t.Errorf("expected an invalid %s hash for %s", testCase.hashFunction, testCase.password)
+		}
+	}
+}
+
+func TestPasswordFingerprint(t *testing.T) {
+	fingerprint, err := passwordFingerprint("testtest123!")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if !isPasswordFingerprint(fingerprint) {
+		t.Fatalf("expected %s to be a fingerprint", fingerprint)
+	}
+
+	if !passwordMatchesFingerprint("testtest123!", fingerprint) {
+		t.Errorf("expected the password to match its fingerprint")
+	}
+
+	if passwordMatchesFingerprint("testtest124!", fingerprint) {
+		t.Errorf("expected a different password not to match the fingerprint")
+	}
+
+	other, err := passwordFingerprint("testtest123!")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if other == fingerprint {
+		t.Errorf("expected fingerprints to be salted")
+	}
+}
+
+func TestUserRevokeAccessOnSuspend(t *testing.T) {
+	r := resourceUser()
+	raw := map[string]interface{}{
+		"primary_email":            "john@domain.ext",
+		"is_suspended":             true,
+		"revoke_tokens_on_suspend": true,
+	}
+
+	// Created suspended
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.MarkNewResource()
+	if !userRevokeAccessOnSuspend(d) {
+		t.Errorf("expected access to be revoked for a user created suspended")
+	}
+
+	// Suspended by an update
+	d = schema.TestResourceDataRaw(t, r.Schema, raw)
+	if !userRevokeAccessOnSuspend(d) {
+		t.Errorf("expected access to be revoked for a user becoming suspended")
+	}
+
+	// Created suspended without revoke_tokens_on_suspend
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"primary_email": "john@domain.ext",
+		"is_suspended":  true,
+	})
+	d.MarkNewResource()
+	if userRevokeAccessOnSuspend(d) {
+		t.Errorf("expected access not to be revoked without revoke_tokens_on_suspend")
+	}
+
+	// Already suspended
+	d = r.Data(&terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"primary_email":            "john@domain.ext",
+			"is_suspended":             "true",
+			"revoke_tokens_on_suspend": "true",
+		},
+	})
+	if userRevokeAccessOnSuspend(d) {
+		t.Errorf("expected access not to be revoked again for a user which is already suspended")
+	}
+}
+
+func TestUserRestoreUnsentPassword(t *testing.T) {
+	r := resourceUser()
+	fingerprint, err := passwordFingerprint("old password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	state := &terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"primary_email": "john@domain.ext",
+			"password":      fingerprint,
+		},
+	}
+
+	// The planned password wasn't sent
+	d := r.Data(state)
+	d.Set("password", "new password")
+	userRestoreUnsentPassword(d)
+	if pw := d.Get("password").(string); pw != fingerprint {
+		t.Errorf("expected the fingerprint of the old password, got %q", pw)
+	}
+
+	// The new password was sent and its fingerprint stored
+	other, err := passwordFingerprint("new password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d = r.Data(state)
+	d.Set("password", other)
+	userRestoreUnsentPassword(d)
+	if pw := d.Get("password").(string); pw != other {
+		t.Errorf("expected the fingerprint of the new password, got %q", pw)
+	}
+}
+
+func TestResourceUserMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"primary_email": "john@domain.ext",
+			"password":      "plain text",
+		},
+	}
+
+	is, err := resourceUserMigrateState(0, is, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !isPasswordFingerprint(is.Attributes["password"]) {
+		t.Errorf("expected the password to be replaced by its fingerprint, got %q", is.Attributes["password"])
+	}
+	if v := is.Attributes["change_password_next_login"]; v != "true" {
+		t.Errorf("expected change_password_next_login to default to true, got %q", v)
+	}
+}
@@ -0,0 +1,304 @@
+package gsuite
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceUsersAttributes() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUsersAttributesCreate,
+		Read:   resourceUsersAttributesRead,
+		Update: resourceUsersAttributesUpdate,
+		Delete: resourceUsersAttributesDelete,
+
+		CustomizeDiff: validateUsersAttributesDiff,
+
+		Schema: map[string]*schema.Schema{
+			// primary email -> JSON object of schema name -> fields, only
+			// these fields are managed.
+			"users": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: suppressEquivalentCustomSchemaValue,
+			},
+
+			// Number of users patched concurrently
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+		},
+	}
+}
+
+// usersAttributes is the parsed form of `users`: user -> schema -> fields
+type usersAttributes map[string]map[string]map[string]interface{}
+
+func usersAttributesFromMap(users map[string]interface{}) (usersAttributes, error) {
+	parsed := usersAttributes{}
+	for email, raw := range users {
+		customSchemas := map[string]map[string]interface{}{}
+		if err := json.Unmarshal([]byte(raw.(string)), &customSchemas); err != nil {
+			return nil, fmt.Errorf("users[%q] is not a JSON object of schema names to fields: %s", email, err)
+		}
+		parsed[email] = customSchemas
+	}
+	return parsed, nil
+}
+
+// schemaNames returns the sorted names of all schemas used by any user.
+func (u usersAttributes) schemaNames() []string {
+	names := map[string]bool{}
+	for _, customSchemas := range u {
+		for name := range customSchemas {
+			names[name] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func validateUsersAttributesDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("users") {
+		return nil
+	}
+	_, err := usersAttributesFromMap(d.Get("users").(map[string]interface{}))
+	return err
+}
+
+func resourceUsersAttributesCreate(d *schema.ResourceData, meta interface{}) error {
+	users, err := usersAttributesFromMap(d.Get("users").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	changes := usersAttributes{}
+	for email, customSchemas := range users {
+		changes[email] = userAttributesDiff(nil, customSchemas)
+	}
+
+	d.SetId(resource.UniqueId())
+	if _, err = usersAttributesApply(d, meta, changes); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Created fields of %d users", len(users))
+	return resourceUsersAttributesRead(d, meta)
+}
+
+func resourceUsersAttributesUpdate(d *schema.ResourceData, meta interface{}) error {
+	o, n := d.GetChange("users")
+	oldUsers, err := usersAttributesFromMap(o.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	newUsers, err := usersAttributesFromMap(n.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	// Only the users with changed fields are patched
+	changes := usersAttributes{}
+	for email, customSchemas := range newUsers {
+		if changed := userAttributesDiff(oldUsers[email], customSchemas); len(changed) > 0 {
+			changes[email] = changed
+		}
+	}
+	for email, customSchemas := range oldUsers {
+		if _, ok := newUsers[email]; !ok {
+			changes[email] = userAttributesNulled(customSchemas)
+		}
+	}
+
+	if failed, err := usersAttributesApply(d, meta, changes); err != nil {
+		// Dropped users whose fields weren't cleared stay in the state, so
+		// the next apply retries the cleanup
+		if err := d.Set("users", usersAttributesKeepFailed(o.(map[string]interface{}), n.(map[string]interface{}), failed)); err != nil {
+			log.Printf("[WARN] Error keeping users whose fields were not cleared in state: %s", err)
+		}
+		return err
+	}
+
+	log.Printf("[INFO] Updated fields of %d users", len(changes))
+	return resourceUsersAttributesRead(d, meta)
+}
+
+func resourceUsersAttributesRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	users, err := usersAttributesFromMap(d.Get("users").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	schemaNames := users.schemaNames()
+	if len(schemaNames) == 0 {
+		return nil
+	}
+
+	// Read all users at once instead of one request per user
+	current := map[string]*directory.User{}
+	token := ""
+	for paginate := true; paginate; {
+		var response *directory.Users
+		err = retry(func() error {
+			response, err = config.directory.Users.List().
+				Customer(config.CustomerId).
+				Projection("custom").
+				CustomFieldMask(strings.Join(schemaNames, ",")).
+				MaxResults(500).
+				PageToken(token).
+				Do()
+			return err
+		}, config.TimeoutMinutes)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error listing users: %s", err)
+		}
+
+		for _, user := range response.Users {
+			current[strings.ToLower(user.PrimaryEmail)] = user
+		}
+		token = response.NextPageToken
+		paginate = token != ""
+	}
+
+	result := map[string]interface{}{}
+	for email, customSchemas := range users {
+		user, ok := current[strings.ToLower(email)]
+		if !ok {
+			log.Printf("[WARN] User %s does not exist anymore, removing it from the state", email)
+			continue
+		}
+
+		// Only read back the fields this resource manages
+		owned := map[string]map[string]interface{}{}
+		for name, fields := range customSchemas {
+			values := map[string]interface{}{}
+			if raw, ok := user.CustomSchemas[name]; ok {
+				if err := json.Unmarshal(raw, &values); err != nil {
+					return fmt.Errorf("Error unmarshalling custom attributes of user %s: %s", email, err)
+				}
+			}
+
+			owned[name] = map[string]interface{}{}
+			for field := range fields {
+				if value, ok := values[field]; ok {
+					owned[name][field] = value
+				}
+			}
+		}
+
+		value, err := json.Marshal(owned)
+		if err != nil {
+			return err
+		}
+		result[email] = string(value)
+	}
+
+	if err = d.Set("users", result); err != nil {
+		return fmt.Errorf("Error setting users in state: %s", err.Error())
+	}
+
+	return nil
+}
+
+func resourceUsersAttributesDelete(d *schema.ResourceData, meta interface{}) error {
+	users, err := usersAttributesFromMap(d.Get("users").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	changes := usersAttributes{}
+	for email, customSchemas := range users {
+		changes[email] = userAttributesNulled(customSchemas)
+	}
+
+	if _, err = usersAttributesApply(d, meta, changes); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// usersAttributesKeepFailed returns the new users, plus the users dropped
+// from the map that failed to be patched, with their old fields.
+func usersAttributesKeepFailed(oldUsers, newUsers map[string]interface{}, failed []string) map[string]interface{} {
+	result := make(map[string]interface{}, len(newUsers))
+	for email, value := range newUsers {
+		result[email] = value
+	}
+	for _, email := range failed {
+		if _, ok := newUsers[email]; ok {
+			continue
+		}
+		if value, ok := oldUsers[email]; ok {
+			result[email] = value
+		}
+	}
+	return result
+}
+
+// usersAttributesApply patches the given fields of every user, batch_size
+// users at a time. It returns the users that failed to be patched.
+func usersAttributesApply(d *schema.ResourceData, meta interface{}, changes usersAttributes) ([]string, error) {
+	config := meta.(*Config)
+	batchSize := d.Get("batch_size").(int)
+
+	emails := make([]string, 0, len(changes))
+	for email := range changes {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+
+	var failed, failures []string
+	for start := 0; start < len(emails); start += batchSize {
+		end := start + batchSize
+		if end > len(emails) {
+			end = len(emails)
+		}
+
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		for _, email := range emails[start:end] {
+			wg.Add(1)
+			go func(email string) {
+				defer wg.Done()
+				log.Printf("[DEBUG] Patching fields of user %s", email)
+				if _, err := userAttributesPatch(config, email, changes[email]); err != nil {
+					mutex.Lock()
+					failed = append(failed, email)
+					failures = append(failures, fmt.Sprintf("%s: %s", email, err))
+					mutex.Unlock()
+				}
+			}(email)
+		}
+		wg.Wait()
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failed)
+		sort.Strings(failures)
+		return failed, fmt.Errorf("[ERROR] Error updating the fields of %d users:\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+
+	return nil, nil
+}
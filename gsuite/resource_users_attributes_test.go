@@ -0,0 +1,57 @@
+package gsuite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUsersAttributesFromMap(t *testing.T) {
+	users, err := usersAttributesFromMap(map[string]interface{}{
+		"a@domain.ext": `{"Employee":{"cost_center":"123"},"Badge":{"number":42}}`,
+		"b@domain.ext": `{"Employee":{"level":3}}`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"Badge", "Employee"}
+	if names := users.schemaNames(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected schema names %v, got %v", expected, names)
+	}
+
+	invalid := []string{
+		`not json`,
+		`["Employee"]`,
+		`{"Employee":"123"}`,
+	}
+	for _, value := range invalid {
+		if _, err := usersAttributesFromMap(map[string]interface{}{"a@domain.ext": value}); err == nil {
+			t.Errorf("expected an error for %s", value)
+		}
+	}
+}
+
+func TestUsersAttributesKeepFailed(t *testing.T) {
+	oldUsers := map[string]interface{}{
+		"a@domain.ext": `{"Employee":{"cost_center":"123"}}`,
+		"b@domain.ext": `{"Employee":{"level":3}}`,
+		"c@domain.ext": `{"Badge":{"number":42}}`,
+	}
+	newUsers := map[string]interface{}{
+		"a@domain.ext": `{"Employee":{"cost_center":"456"}}`,
+	}
+
+	// b failed to be cleared and is kept, c was cleared, a keeps its new value
+	expected := map[string]interface{}{
+		"a@domain.ext": `{"Employee":{"cost_center":"456"}}`,
+		"b@domain.ext": `{"Employee":{"level":3}}`,
+	}
+	result := usersAttributesKeepFailed(oldUsers, newUsers, []string{"a@domain.ext", "b@domain.ext"})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	if result := usersAttributesKeepFailed(oldUsers, newUsers, nil); !reflect.DeepEqual(result, newUsers) {
+		t.Errorf("expected %v without failures, got %v", newUsers, result)
+	}
+}
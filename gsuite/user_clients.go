@@ -0,0 +1,101 @@
+package gsuite
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/licensing/v1"
+	"google.golang.org/api/option"
+)
+
+// Scopes requested when acting as a user, per API. Only the scopes needed are
+// requested, each must be allowed in the domain-wide delegation of the
+// service account.
+var (
+	gmailSettingsScopes = []string{gmail.GmailSettingsBasicScope, gmail.GmailSettingsSharingScope}
+	gmailLabelsScopes   = []string{gmail.GmailLabelsScope}
+	calendarScopes      = []string{calendar.CalendarScope}
+	driveScopes         = []string{drive.DriveScope}
+	licensingScopes     = []string{licensing.AppsLicensingScope}
+)
+
+// Number of users whose clients are kept, the least recently used are evicted
+const defaultUserClientCacheSize = 100
+
+// userClientCache keeps the clients used to act as users of the domain, so
+// the tokens they mint are reused across resources. It is safe for
+// concurrent use.
+type userClientCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// Most recently used first
+	order *list.List
+}
+
+type userClientEntry struct {
+	key     string
+	options []option.ClientOption
+}
+
+func newUserClientCache(size int) *userClientCache {
+	return &userClientCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// userClientKey identifies the clients of a subject with a set of scopes.
+func userClientKey(subject string, scopes []string) string {
+	sorted := append([]string{}, scopes...)
+	sort.Strings(sorted)
+	return strings.ToLower(subject) + " " + strings.Join(sorted, " ")
+}
+
+// get returns the cached client options for key, calling create when they are
+// not cached yet. create is called without holding the lock, when two callers
+// race the first options stored win.
+func (c *userClientCache) get(key string, create func() ([]option.ClientOption, error)) ([]option.ClientOption, error) {
+	c.mutex.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		c.mutex.Unlock()
+		return element.Value.(*userClientEntry).options, nil
+	}
+	c.mutex.Unlock()
+
+	options, err := create()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*userClientEntry).options, nil
+	}
+
+	c.entries[key] = c.order.PushFront(&userClientEntry{key: key, options: options})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*userClientEntry).key)
+	}
+
+	return options, nil
+}
+
+// len returns the number of cached clients.
+func (c *userClientCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,122 @@
+package gsuite
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/option"
+)
+
+func TestUserClientKey(t *testing.T) {
+	a := userClientKey("John@domain.ext", []string{"b", "a"})
+	b := userClientKey("john@domain.ext", []string{"a", "b"})
+	if a != b {
+		t.Errorf("expected the same key for the same subject and scopes, got %q and %q", a, b)
+	}
+	if a == userClientKey("john@domain.ext", []string{"a"}) {
+		t.Error("expected a different key for different scopes")
+	}
+}
+
+func TestUserClientCache(t *testing.T) {
+	cache := newUserClientCache(2)
+
+	created := map[string]int{}
+	get := func(key string) []option.ClientOption {
+		options, err := cache.get(key, func() ([]option.ClientOption, error) {
+			created[key]++
+			return []option.ClientOption{option.WithUserAgent(key)}, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return options
+	}
+
+	a := get("a")
+	get("b")
+	if again := get("a"); &again[0] != &a[0] {
+		t.Error("expected the cached options of a")
+	}
+
+	// b is the least recently used
+	get("c")
+	if cache.len() != 2 {
+		t.Errorf("expected 2 cached clients, got %d", cache.len())
+	}
+	get("a")
+	get("b")
+
+	expected := map[string]int{"a": 1, "b": 2, "c": 1}
+	for key, count := range expected {
+		if created[key] != count {
+			t.Errorf("expected %s to be created %d times, got %d", key, count, created[key])
+		}
+	}
+
+	if _, err := cache.get("d", func() ([]option.ClientOption, error) {
+		return nil, fmt.Errorf("no credentials")
+	}); err == nil {
+		t.Error("expected the error of create")
+	}
+	if cache.len() != 2 {
+		t.Errorf("expected errors not to be cached, got %d clients", cache.len())
+	}
+}
+
+func TestUserClientCacheConcurrent(t *testing.T) {
+	cache := newUserClientCache(10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("user%d", i%20)
+			if _, err := cache.get(key, func() ([]option.ClientOption, error) {
+				return []option.ClientOption{option.WithUserAgent(key)}, nil
+			}); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if cache.len() != 10 {
+		t.Errorf("expected 10 cached clients, got %d", cache.len())
+	}
+}
+
+func TestConfigUserClientOptions(t *testing.T) {
+	config := Config{
+		Credentials:           testFakeCredentialsPath,
+		ImpersonatedUserEmail: "xxx@xxx.xom",
+	}
+	if err := config.loadAndValidate("0.12"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	a, err := config.userClientOptions("john@domain.ext", gmailSettingsScopes)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	b, err := config.userClientOptions("John@domain.ext", gmailSettingsScopes)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if &a[0] != &b[0] {
+		t.Error("expected the client of the user to be reused")
+	}
+
+	if _, err := config.userClientOptions("john@domain.ext", gmailLabelsScopes); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if config.userClients.len() != 2 {
+		t.Errorf("expected a client per set of scopes, got %d", config.userClients.len())
+	}
+
+	if _, err := (&Config{}).userClientOptions("john@domain.ext", gmailSettingsScopes); err == nil {
+		t.Error("expected an error without domain-wide delegation")
+	}
+}
@@ -3,6 +3,9 @@
 package gsuite
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"math/rand"
@@ -12,6 +15,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
 	"google.golang.org/api/googleapi"
 )
 
@@ -204,3 +209,43 @@ func validateEmail(v interface{}, k string) (warnings []string, errors []error)
 
 	return
 }
+
+// encryptWithPGPKey encrypts value to the given public key, which is either
+// base64 encoded or ASCII armored. It returns the base64 encoded ciphertext and
+// the fingerprint of the key that was used.
+func encryptWithPGPKey(value, key string) (string, string, error) {
+	var entity *openpgp.Entity
+	if strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			return "", "", fmt.Errorf("error parsing armored PGP key: %s", err)
+		}
+		if len(entities) == 0 {
+			return "", "", fmt.Errorf("no PGP key found")
+		}
+		entity = entities[0]
+	} else {
+		data, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return "", "", fmt.Errorf("error decoding base64 PGP key: %s", err)
+		}
+		entity, err = openpgp.ReadEntity(packet.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return "", "", fmt.Errorf("error parsing PGP key: %s", err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := openpgp.Encrypt(buf, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("error encrypting with PGP key: %s", err)
+	}
+	if _, err = w.Write([]byte(value)); err != nil {
+		return "", "", fmt.Errorf("error encrypting with PGP key: %s", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", "", fmt.Errorf("error encrypting with PGP key: %s", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]), nil
+}
@@ -1,7 +1,13 @@
 package gsuite
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
 	"testing"
+
+	"golang.org/x/crypto/openpgp"
 )
 
 func TestValidateEmail(t *testing.T) {
@@ -27,3 +33,53 @@ func TestValidateEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestEncryptWithPGPKey(t *testing.T) {
+	entity, err := openpgp.NewEntity("gsuite", "test", "gsuite@domain.ext", nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	// Keys generated by gpg advertise their preferred hashes, do the same
+	for _, id := range entity.Identities {
+		id.SelfSignature.PreferredHash = []uint8{8} // SHA256
+		if err = id.SelfSignature.SignUserId(id.UserId.Id, entity.PrimaryKey, entity.PrivateKey, nil); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err = entity.Serialize(buf); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	encrypted, fingerprint, err := encryptWithPGPKey("testtest123!", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if fingerprint != hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]) {
+		t.Errorf("unexpected fingerprint %s", fingerprint)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	md, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	plaintext, err := ioutil.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if string(plaintext) != "testtest123!" {
+		t.Errorf("expected the decrypted value to match, got %s", plaintext)
+	}
+
+	if _, _, err = encryptWithPGPKey("testtest123!", "not a key"); err == nil {
+		t.Errorf("expected an error for an invalid key")
+	}
+}